@@ -69,7 +69,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Saves an order book for a specific exchange and pair.",
+                "description": "Saves an order book for a specific exchange and pair, the exchange must be registered and enabled.",
                 "consumes": [
                     "application/json"
                 ],
@@ -112,6 +112,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/market-info-storage_internal_controllers_httputils.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Exchange is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/market-info-storage_internal_controllers_httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange is not registered",
+                        "schema": {
+                            "$ref": "#/definitions/market-info-storage_internal_controllers_httputils.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Order Book violates invariants",
+                        "schema": {
+                            "$ref": "#/definitions/market-info-storage_internal_controllers_httputils.HTTPValidationError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -186,7 +204,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Saves an order.",
+                "description": "Saves an order. With instrument validation enabled an order of a pair with a registered instrument is rejected if its price is off tick, its quantity off step or its notional below the minimum.",
                 "consumes": [
                     "application/json"
                 ],
@@ -243,6 +261,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/market-info-storage_internal_controllers_httputils.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Exchange is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/market-info-storage_internal_controllers_httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange is not registered",
+                        "schema": {
+                            "$ref": "#/definitions/market-info-storage_internal_controllers_httputils.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Order breaks the trading rules of the pair's instrument",
+                        "schema": {
+                            "$ref": "#/definitions/market-info-storage_internal_controllers_httputils.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -297,19 +333,19 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "baseQty": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "commissionQuoteQty": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "highestBuyPrc": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "lowestSellPrc": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "price": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "side": {
                     "type": "string"
@@ -352,14 +388,28 @@ const docTemplate = `{
                 }
             }
         },
+        "market-info-storage_internal_controllers_httputils.HTTPValidationError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/market-info-storage_internal_domain.LevelViolation"
+                    }
+                }
+            }
+        },
         "market-info-storage_internal_domain.DepthOrder": {
             "type": "object",
             "properties": {
                 "baseQty": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "price": {
-                    "type": "number"
+                    "type": "string"
                 }
             }
         },
@@ -370,31 +420,31 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "baseQty": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "clinet": {
                     "type": "string"
                 },
                 "commissionQuoteQty": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "exchangeName": {
                     "type": "string"
                 },
                 "highestBuyPrc": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "label": {
                     "type": "string"
                 },
                 "lowestSellPrc": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "pair": {
                     "type": "string"
                 },
                 "price": {
-                    "type": "number"
+                    "type": "string"
                 },
                 "side": {
                     "type": "string"
@@ -406,6 +456,31 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "market-info-storage_internal_domain.LevelViolation": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "side": {
+                    "$ref": "#/definitions/market-info-storage_internal_domain.Side"
+                }
+            }
+        },
+        "market-info-storage_internal_domain.Side": {
+            "type": "string",
+            "enum": [
+                "bid",
+                "ask"
+            ],
+            "x-enum-varnames": [
+                "SideBid",
+                "SideAsk"
+            ]
         }
     }
 }`
@@ -0,0 +1,2074 @@
+// Package v2 Code generated by swaggo/swag. DO NOT EDIT
+package v2
+
+import "github.com/swaggo/swag"
+
+const docTemplatev2 = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/admin/exchanges": {
+            "get": {
+                "description": "Lists registered exchanges with their settings, including disabled ones, sorted by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Registered Exchanges",
+                "responses": {
+                    "200": {
+                        "description": "Exchanges",
+                        "schema": {
+                            "$ref": "#/definitions/exchangecontroller.getExchangesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchanges/{exchange}": {
+            "get": {
+                "description": "Retrieves registry settings of an exchange.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get Exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exchange",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Exchange"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange not found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Registers an exchange or replaces its settings. Order books, deltas and history orders are accepted only for registered and enabled exchanges. Fees are rates such as 0.001 for 0.1%, the taker fee overrides the configured arbitrage taker fee and the validation mode the configured one. Snapshots older than the snapshot retention are deleted periodically.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Save Exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exchange settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/exchangecontroller.saveExchangeRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved exchange",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Exchange"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid exchange settings",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes an exchange from the registry, so that its data is no longer accepted. Stored data of the exchange is kept.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete Exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange not found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/arbitrage/opportunities": {
+            "get": {
+                "description": "Lists pairs whose best bid on one exchange exceeds the best ask on another after taker fees, using the latest stored order books. Stale books are skipped. Size and profit are calculated by walking the top levels of both books. Opportunities are sorted by profit in descending order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Arbitrage"
+                ],
+                "summary": "Find Arbitrage Opportunities",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Currency Pairs, all pairs if none is chosen",
+                        "name": "pair",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Exchange names, all exchanges if none is chosen",
+                        "name": "exchange",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Save detected opportunities for later review",
+                        "name": "record",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Arbitrage Opportunities",
+                        "schema": {
+                            "$ref": "#/definitions/arbitragecontroller.findArbitrageOpportunitiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "Streams order book updates and deletions and saved history orders as Server-Sent Events with increasing IDs. A client reconnecting with the Last-Event-ID header is first sent the events it missed. If they are no longer kept, a snapshot-required event is sent instead and the client has to reload the current state before applying further events. Event IDs are specific to the server instance.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream Events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the last received event",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "order-book",
+                                "order-book-deleted",
+                                "history-order"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Event types, all types if none is chosen",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Exchange names, all exchanges if none is chosen",
+                        "name": "exchange",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges": {
+            "get": {
+                "description": "Lists exchanges with stored order books or history orders. Base and quote filter exchanges by the assets of their pairs, pair counts include only matching pairs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discovery"
+                ],
+                "summary": "Get Exchanges",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base asset",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote asset",
+                        "name": "quote",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exchanges",
+                        "schema": {
+                            "$ref": "#/definitions/discoverycontroller.getExchangesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/{exchange}/instruments": {
+            "get": {
+                "description": "Lists instruments of an exchange sorted by pair.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Instrument"
+                ],
+                "summary": "Get Instruments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instruments",
+                        "schema": {
+                            "$ref": "#/definitions/instrumentcontroller.getInstrumentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/{exchange}/instruments/{pair}": {
+            "get": {
+                "description": "Retrieves the instrument of a pair on an exchange.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Instrument"
+                ],
+                "summary": "Get Instrument",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instrument",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Instrument"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Instrument not found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Creates or replaces the instrument of a pair on an exchange. Base and quote assets are taken from the pair if omitted. Tick size and quantity step must be positive, a minimum notional of 0 sets no minimum. The exchange must be registered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Instrument"
+                ],
+                "summary": "Save Instrument",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Instrument",
+                        "name": "instrument",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/instrumentcontroller.saveInstrumentRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved instrument",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Instrument"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange is not registered",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Invalid instrument",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the instrument of a pair on an exchange, its order books and history orders are no longer validated against it.",
+                "tags": [
+                    "Instrument"
+                ],
+                "summary": "Delete Instrument",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Instrument not found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/{exchange}/order-books": {
+            "delete": {
+                "description": "Deletes current order books of all pairs of the exchange. The snapshot history is kept.",
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Delete Exchange Order Books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "No Order Books found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/{exchange}/pairs": {
+            "get": {
+                "description": "Lists pairs of the exchange with stored order books or history orders, with the update time and level counts of the order book and the number of history orders. Pairs that can not be split into base and quote assets do not match base and quote filters.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Discovery"
+                ],
+                "summary": "Get Exchange Pairs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Base asset",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote asset",
+                        "name": "quote",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pairs",
+                        "schema": {
+                            "$ref": "#/definitions/discoverycontroller.getExchangePairsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange not found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/{exchange}/pairs/{pair}/order-book": {
+            "get": {
+                "description": "Retrieves the order book for a specific exchange and pair with bids and asks returned separately. With the at parameter the latest snapshot saved at or before that moment is returned. Depth limits the number of levels per side, side restricts the result to bids or asks, the other side is returned empty. The Last-Modified header carries the time the book was last updated and the ETag header its version, followed by depth, side, group and precision when they are set as they change the levels, a request with If-None-Match matching the current ETag is answered with 304 Not Modified. With max_age a book updated longer ago is rejected as stale instead of being returned. With precision=instrument prices and quantities are formatted with the decimal places of the tick size and quantity step of the pair's instrument, keeping trailing zeros, values with more decimal places are returned unrounded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Get Order Book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Point in time, RFC 3339",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of levels per side, 0 for all levels",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "bid",
+                            "ask"
+                        ],
+                        "type": "string",
+                        "description": "Side to return",
+                        "name": "side",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Price step to group levels by",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum age of the book, Go duration such as 5s",
+                        "name": "max_age",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "instrument"
+                        ],
+                        "type": "string",
+                        "description": "Format levels with the precision of the pair's instrument",
+                        "name": "precision",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags of the book known to the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order Book data",
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.getOrderBookResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the book and the parameters shaping its levels"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Time the book was last updated"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Order Book has not changed"
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Order Book or instrument not found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Order Book is older than max_age",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Saves an order book for a specific exchange and pair, the exchange must be registered and enabled. Bids and asks are passed separately and may have different lengths. Levels are validated according to the validation mode of the exchange. An optional checksum published by the exchange is verified against the received levels, with the algorithm of the exchange unless another one is given. Saving a snapshot clears the resync required state set by rejected deltas and the stale flag. With If-Match the book is saved only if the stored book has one of the given ETags, * matches any stored book.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Save Order Book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags the stored book must have",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Order Book data",
+                        "name": "orderBook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.saveOrderBookRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the saved book"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Exchange is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange is not registered",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Stored Order Book does not match If-Match",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Order Book violates invariants or does not match the checksum",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the current order book of a delisted pair so it is no longer served. The snapshot history is kept.",
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Delete Order Book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Order Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Upserts levels of the stored order book of a registered and enabled exchange, a level with zero baseQty removes the level with the same price. The delta sequence number must directly follow the sequence number of the last applied snapshot or delta, otherwise the delta is rejected and all further deltas are rejected until a new snapshot is saved. An optional checksum is verified against the book with the delta applied, a mismatch leaves the stored book unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Apply Order Book Delta",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Order Book delta",
+                        "name": "delta",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.applyOrderBookDeltaRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Exchange is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Order Book not found or exchange is not registered",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Resync required",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Delta or resulting Order Book violates invariants or does not match the checksum",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPValidationError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/{exchange}/pairs/{pair}/order-book/impact": {
+            "get": {
+                "description": "Walks the stored order book with a market order sized either in base quantity or in quote notional and returns the VWAP fill price, the worst price reached, the number of levels consumed, slippage versus the mid price in basis points and the unfilled remainder.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Estimate Market Impact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "buy",
+                            "sell"
+                        ],
+                        "type": "string",
+                        "description": "Order side",
+                        "name": "side",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Order size in base asset",
+                        "name": "base-qty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Order size in quote asset",
+                        "name": "quote-qty",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Market impact",
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.estimateMarketImpactResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Order Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/{exchange}/pairs/{pair}/order-book/snapshots": {
+            "get": {
+                "description": "Lists times of order book snapshots saved for a specific exchange and pair within [from, to] in ascending order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "List Order Book Snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range start, RFC 3339",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range end, RFC 3339",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1000,
+                        "description": "Maximum number of snapshots",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Snapshot times",
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.getOrderBookSnapshotTimesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchanges/{exchange}/pairs/{pair}/order-book/stats": {
+            "get": {
+                "description": "Computes best bid and ask, mid price, spread in absolute terms and in basis points, microprice and volume imbalance over the top levels of the stored order book. Metrics that need both sides are null if one of the sides is empty.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Get Order Book Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "exchange",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of top levels per side used for imbalance",
+                        "name": "levels",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order Book stats",
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.getOrderBookStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Order Book not found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/order-books": {
+            "put": {
+                "description": "Saves many order books in one transaction. Every book is validated according to the validation mode of its exchange, books of exchanges that are not registered or disabled, invalid books and books that do not match their checksum are reported with their index in the batch while the valid ones are saved. In strict mode any rejected book rejects the whole batch with 422.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Save Order Books",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Reject the whole batch if any book is invalid",
+                        "name": "strict",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Order Books",
+                        "name": "orderBooks",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.saveOrderBooksRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of saved Order Books and rejected Order Books",
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.saveOrderBooksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Batch rejected in strict mode",
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.saveOrderBooksResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/order-books/stream": {
+            "get": {
+                "description": "Upgrades the connection to WebSocket and streams the chosen order books. The current state of every existing book is sent as a snapshot message right away, every later save or delta is sent as an update message with the whole book and a deletion of the book as a deleted message without levels. Without conflation a client that falls behind by more than the configured number of updates is disconnected with close code 1008, with conflation only the latest state of every book is kept for it.",
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Stream Order Books",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Order Books as exchange:pair",
+                        "name": "book",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Send only the latest state of every book to a slow client",
+                        "name": "conflate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of levels per side, 0 for all levels",
+                        "name": "depth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/pairs/{pair}/order-book": {
+            "get": {
+                "description": "Merges stored order books of the pair from the chosen exchanges, or from all exchanges if none is chosen. Every level carries quantities contributed by each exchange. Side and group are applied to every book before merging, depth is applied to the merged levels.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OrderBook"
+                ],
+                "summary": "Get Consolidated Order Book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency Pair",
+                        "name": "pair",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Exchange names",
+                        "name": "exchange",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of levels per side, 0 for all levels",
+                        "name": "depth",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "bid",
+                            "ask"
+                        ],
+                        "type": "string",
+                        "description": "Side to return",
+                        "name": "side",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Price step to group levels by",
+                        "name": "group",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Consolidated Order Book",
+                        "schema": {
+                            "$ref": "#/definitions/orderbookcontroller.getConsolidatedOrderBookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "No Order Books found",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/httputils.HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "arbitragecontroller.findArbitrageOpportunitiesResponse": {
+            "type": "object",
+            "properties": {
+                "opportunities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ArbitrageOpportunity"
+                    }
+                }
+            }
+        },
+        "discoverycontroller.getExchangePairsResponse": {
+            "type": "object",
+            "properties": {
+                "pairs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PairSummary"
+                    }
+                }
+            }
+        },
+        "discoverycontroller.getExchangesResponse": {
+            "type": "object",
+            "properties": {
+                "exchanges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ExchangeSummary"
+                    }
+                }
+            }
+        },
+        "domain.ArbitrageOpportunity": {
+            "type": "object",
+            "properties": {
+                "baseQty": {
+                    "description": "BaseQty is the size executable at a profit by walking both books, summed exactly from level quantities.",
+                    "type": "string"
+                },
+                "bestAsk": {
+                    "description": "BestAsk on the buy exchange and BestBid on the sell exchange.",
+                    "type": "string"
+                },
+                "bestBid": {
+                    "type": "string"
+                },
+                "buyExchange": {
+                    "type": "string"
+                },
+                "buyQuoteQty": {
+                    "description": "BuyQuoteQty is the cost including fees, SellQuoteQty is the proceeds net of fees.\nBeing fee adjusted estimates they are float64 as is the profit.",
+                    "type": "number"
+                },
+                "detectedAt": {
+                    "type": "string"
+                },
+                "pair": {
+                    "type": "string"
+                },
+                "profit": {
+                    "type": "number"
+                },
+                "profitBps": {
+                    "type": "number"
+                },
+                "sellExchange": {
+                    "type": "string"
+                },
+                "sellQuoteQty": {
+                    "type": "number"
+                }
+            }
+        },
+        "domain.ConsolidatedDepthOrder": {
+            "type": "object",
+            "properties": {
+                "baseQty": {
+                    "type": "string"
+                },
+                "exchanges": {
+                    "description": "Exchanges holds the quantity each exchange contributes to the level.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DepthOrder": {
+            "type": "object",
+            "properties": {
+                "baseQty": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Exchange": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "makerFee": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "snapshotRetentionHours": {
+                    "description": "SnapshotRetentionHours is how long order book snapshots are kept, 0 keeps them forever.\nA snapshot keeps the retention it was saved with.",
+                    "type": "integer"
+                },
+                "takerFee": {
+                    "description": "TakerFee and MakerFee are fee rates, for example 0.001 for 0.1%.\nA nil TakerFee falls back to the configured arbitrage taker fee.",
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "validationMode": {
+                    "description": "ValidationMode overrides the configured order book validation mode unless empty.",
+                    "type": "string",
+                    "enum": [
+                        "strict",
+                        "lenient"
+                    ]
+                }
+            }
+        },
+        "domain.ExchangeSummary": {
+            "type": "object",
+            "properties": {
+                "lastUpdatedAt": {
+                    "description": "LastUpdatedAt is the latest update time of the exchange's order books.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orderBookCount": {
+                    "type": "integer"
+                },
+                "pairCount": {
+                    "description": "PairCount is the number of pairs with an order book or history orders.",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Instrument": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string"
+                },
+                "exchange": {
+                    "type": "string"
+                },
+                "minNotional": {
+                    "description": "MinNotional is the minimum quote quantity of an order, 0 for no minimum.",
+                    "type": "string"
+                },
+                "pair": {
+                    "type": "string"
+                },
+                "qtyStep": {
+                    "description": "QtyStep is the base quantity step, quantities must be its multiples.",
+                    "type": "string"
+                },
+                "quote": {
+                    "type": "string"
+                },
+                "tickSize": {
+                    "description": "TickSize is the price step, prices must be its multiples.",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.LevelViolation": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "side": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MarketImpact": {
+            "type": "object",
+            "properties": {
+                "filledBaseQty": {
+                    "description": "FilledBaseQty and FilledQuoteQty are the executed amounts.",
+                    "type": "number"
+                },
+                "filledQuoteQty": {
+                    "type": "number"
+                },
+                "levelsConsumed": {
+                    "type": "integer"
+                },
+                "side": {
+                    "type": "string"
+                },
+                "slippageBps": {
+                    "description": "SlippageBps is the cost of VWAPPrice relative to the mid price in basis points,\nnil if nothing is filled or one of the book sides is empty.",
+                    "type": "number"
+                },
+                "unfilledBaseQty": {
+                    "description": "UnfilledBaseQty or UnfilledQuoteQty, whichever the order was sized in,\nis the remainder the book was not deep enough for.",
+                    "type": "number"
+                },
+                "unfilledQuoteQty": {
+                    "type": "number"
+                },
+                "vwapPrice": {
+                    "description": "VWAPPrice is the volume weighted fill price, nil if nothing is filled.",
+                    "type": "number"
+                },
+                "worstPrice": {
+                    "description": "WorstPrice is the exact price of the last level reached, nil if nothing is filled.",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OrderBookBatchFailure": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "exchange": {
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Index is the position of the book in the batch.",
+                    "type": "integer"
+                },
+                "pair": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LevelViolation"
+                    }
+                }
+            }
+        },
+        "domain.OrderBookStats": {
+            "type": "object",
+            "properties": {
+                "bestAsk": {
+                    "$ref": "#/definitions/domain.DepthOrder"
+                },
+                "bestBid": {
+                    "$ref": "#/definitions/domain.DepthOrder"
+                },
+                "imbalance": {
+                    "description": "Imbalance is (bidQty - askQty) / (bidQty + askQty) over the top ImbalanceLevels\nlevels of each side, ranging from -1 (only asks) to 1 (only bids).",
+                    "type": "number"
+                },
+                "imbalanceLevels": {
+                    "type": "integer"
+                },
+                "microprice": {
+                    "description": "Microprice is the mid price weighted by the opposite side top level quantities.",
+                    "type": "number"
+                },
+                "mid": {
+                    "type": "number"
+                },
+                "spread": {
+                    "type": "number"
+                },
+                "spreadBps": {
+                    "description": "SpreadBps is the spread relative to the mid price in basis points.",
+                    "type": "number"
+                }
+            }
+        },
+        "domain.OrderBookSummary": {
+            "type": "object",
+            "properties": {
+                "askLevels": {
+                    "type": "integer"
+                },
+                "bidLevels": {
+                    "type": "integer"
+                },
+                "stale": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PairSummary": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string"
+                },
+                "historyOrderCount": {
+                    "type": "integer"
+                },
+                "lastHistoryOrderAt": {
+                    "type": "string"
+                },
+                "orderBook": {
+                    "$ref": "#/definitions/domain.OrderBookSummary"
+                },
+                "pair": {
+                    "type": "string"
+                },
+                "quote": {
+                    "type": "string"
+                }
+            }
+        },
+        "exchangecontroller.getExchangesResponse": {
+            "type": "object",
+            "properties": {
+                "exchanges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Exchange"
+                    }
+                }
+            }
+        },
+        "exchangecontroller.saveExchangeRequestBody": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "description": "DisplayName defaults to the exchange name.",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Enabled defaults to true.",
+                    "type": "boolean"
+                },
+                "makerFee": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "snapshotRetentionHours": {
+                    "description": "SnapshotRetentionHours of 0 keeps snapshots forever, a change applies to snapshots saved after it.",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "takerFee": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "validationMode": {
+                    "type": "string",
+                    "enum": [
+                        "strict",
+                        "lenient"
+                    ]
+                }
+            }
+        },
+        "httputils.HTTPError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "httputils.HTTPValidationError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LevelViolation"
+                    }
+                }
+            }
+        },
+        "instrumentcontroller.getInstrumentsResponse": {
+            "type": "object",
+            "properties": {
+                "instruments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Instrument"
+                    }
+                }
+            }
+        },
+        "instrumentcontroller.saveInstrumentRequestBody": {
+            "type": "object",
+            "required": [
+                "qtyStep",
+                "tickSize"
+            ],
+            "properties": {
+                "base": {
+                    "description": "Base and Quote are taken from the pair if omitted.",
+                    "type": "string"
+                },
+                "minNotional": {
+                    "type": "string"
+                },
+                "qtyStep": {
+                    "type": "string"
+                },
+                "quote": {
+                    "type": "string"
+                },
+                "tickSize": {
+                    "type": "string"
+                }
+            }
+        },
+        "orderbookcontroller.applyOrderBookDeltaRequestBody": {
+            "type": "object",
+            "required": [
+                "sequence"
+            ],
+            "properties": {
+                "asks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DepthOrder"
+                    }
+                },
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DepthOrder"
+                    }
+                },
+                "checksum": {
+                    "description": "Checksum is the CRC32 checksum of the top levels of the book with the delta applied.",
+                    "type": "integer"
+                },
+                "checksumAlgorithm": {
+                    "description": "ChecksumAlgorithm is the algorithm of Checksum: okx or kraken, the exchange name is used if empty.",
+                    "type": "string"
+                },
+                "exchangeTime": {
+                    "description": "ExchangeTime is the time the exchange reported for the delta.",
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "orderbookcontroller.estimateMarketImpactResponse": {
+            "type": "object",
+            "properties": {
+                "impact": {
+                    "$ref": "#/definitions/domain.MarketImpact"
+                }
+            }
+        },
+        "orderbookcontroller.getConsolidatedOrderBookResponse": {
+            "type": "object",
+            "properties": {
+                "asks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ConsolidatedDepthOrder"
+                    }
+                },
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ConsolidatedDepthOrder"
+                    }
+                }
+            }
+        },
+        "orderbookcontroller.getOrderBookResponse": {
+            "type": "object",
+            "properties": {
+                "asks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DepthOrder"
+                    }
+                },
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DepthOrder"
+                    }
+                },
+                "exchangeTime": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "stale": {
+                    "description": "Stale is set when the book stopped updating.",
+                    "type": "boolean"
+                },
+                "time": {
+                    "description": "Time is the time the snapshot was saved, set only for point-in-time requests.",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "UpdatedAt is the time the book was last saved or updated by a delta.",
+                    "type": "string"
+                }
+            }
+        },
+        "orderbookcontroller.getOrderBookSnapshotTimesResponse": {
+            "type": "object",
+            "properties": {
+                "times": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "orderbookcontroller.getOrderBookStatsResponse": {
+            "type": "object",
+            "properties": {
+                "stats": {
+                    "$ref": "#/definitions/domain.OrderBookStats"
+                }
+            }
+        },
+        "orderbookcontroller.saveOrderBookRequestBody": {
+            "type": "object",
+            "required": [
+                "asks",
+                "bids"
+            ],
+            "properties": {
+                "asks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DepthOrder"
+                    }
+                },
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DepthOrder"
+                    }
+                },
+                "checksum": {
+                    "description": "Checksum is the CRC32 checksum of the top levels published by the exchange, signed or unsigned.",
+                    "type": "integer"
+                },
+                "checksumAlgorithm": {
+                    "description": "ChecksumAlgorithm is the algorithm of Checksum: okx or kraken, the exchange name is used if empty.",
+                    "type": "string"
+                },
+                "exchangeTime": {
+                    "description": "ExchangeTime is the time the exchange reported for the snapshot.",
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "Sequence is the sequence number of the snapshot, the next delta must carry Sequence+1.",
+                    "type": "integer"
+                }
+            }
+        },
+        "orderbookcontroller.saveOrderBooksRequestBody": {
+            "type": "object",
+            "required": [
+                "orderBooks"
+            ],
+            "properties": {
+                "orderBooks": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/orderbookcontroller.saveOrderBooksRequestItem"
+                    }
+                }
+            }
+        },
+        "orderbookcontroller.saveOrderBooksRequestItem": {
+            "type": "object",
+            "required": [
+                "asks",
+                "bids",
+                "exchange",
+                "pair"
+            ],
+            "properties": {
+                "asks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DepthOrder"
+                    }
+                },
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DepthOrder"
+                    }
+                },
+                "checksum": {
+                    "description": "Checksum is the CRC32 checksum of the top levels published by the exchange, signed or unsigned.",
+                    "type": "integer"
+                },
+                "checksumAlgorithm": {
+                    "description": "ChecksumAlgorithm is the algorithm of Checksum: okx or kraken, the exchange name is used if empty.",
+                    "type": "string"
+                },
+                "exchange": {
+                    "type": "string"
+                },
+                "exchangeTime": {
+                    "type": "string"
+                },
+                "pair": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                }
+            }
+        },
+        "orderbookcontroller.saveOrderBooksResponse": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OrderBookBatchFailure"
+                    }
+                },
+                "saved": {
+                    "description": "Saved is the number of saved order books.",
+                    "type": "integer"
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfov2 holds exported Swagger Info so clients can modify it
+var SwaggerInfov2 = &swag.Spec{
+	Version:          "2.0",
+	Host:             "",
+	BasePath:         "/api/v2",
+	Schemes:          []string{},
+	Title:            "Market info storage",
+	Description:      "API to store and retreive market data",
+	InfoInstanceName: "v2",
+	SwaggerTemplate:  docTemplatev2,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfov2.InstanceName(), SwaggerInfov2)
+}
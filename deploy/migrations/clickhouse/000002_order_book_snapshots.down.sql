@@ -0,0 +1 @@
+DROP TABLE order_book_snapshots;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS order_book_snapshots (
+    exchange String,
+    pair String,
+    time DateTime64(6),
+    bid_prices Array(Float64),
+    bid_qtys Array(Float64),
+    ask_prices Array(Float64),
+    ask_qtys Array(Float64),
+    sequence Nullable(Int64)
+)
+ENGINE = MergeTree()
+ORDER BY (exchange, pair, time);
@@ -0,0 +1 @@
+DROP TABLE arbitrage_opportunities;
\ No newline at end of file
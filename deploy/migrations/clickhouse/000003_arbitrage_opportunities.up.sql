@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS arbitrage_opportunities (
+    pair String,
+    buy_exchange String,
+    sell_exchange String,
+    best_ask Float64,
+    best_bid Float64,
+    base_qty Float64,
+    buy_quote_qty Float64,
+    sell_quote_qty Float64,
+    profit Float64,
+    profit_bps Float64,
+    detected_at DateTime64(6)
+)
+ENGINE = MergeTree()
+ORDER BY (pair, detected_at);
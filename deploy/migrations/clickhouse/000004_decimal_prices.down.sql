@@ -0,0 +1,12 @@
+ALTER TABLE history_orders
+    MODIFY COLUMN base_qty Float64,
+    MODIFY COLUMN price Float64,
+    MODIFY COLUMN lowest_sell_prc Float64,
+    MODIFY COLUMN highest_buy_prc Float64,
+    MODIFY COLUMN commission_quote_qty Float64;
+
+ALTER TABLE order_book_snapshots
+    MODIFY COLUMN bid_prices Array(Float64),
+    MODIFY COLUMN bid_qtys Array(Float64),
+    MODIFY COLUMN ask_prices Array(Float64),
+    MODIFY COLUMN ask_qtys Array(Float64);
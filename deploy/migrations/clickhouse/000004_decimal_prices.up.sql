@@ -0,0 +1,67 @@
+-- Float64 values are converted through their shortest text representation,
+-- so that 0.010782342 does not become 0.010782341999999999.
+CREATE TABLE history_orders_decimal (
+    client_name String,
+    exchange_name String,
+    label String,
+    pair String,
+    side String,
+    type String,
+    base_qty Decimal(38, 18),
+    price Decimal(38, 18),
+    algorithm_name_placed String,
+    lowest_sell_prc Decimal(38, 18),
+    highest_buy_prc Decimal(38, 18),
+    commission_quote_qty Decimal(38, 18),
+    time_placed DateTime
+)
+ENGINE = MergeTree()
+ORDER BY (exchange_name, pair, label, client_name);
+
+INSERT INTO history_orders_decimal
+SELECT
+    client_name,
+    exchange_name,
+    label,
+    pair,
+    side,
+    type,
+    toDecimal128(toString(base_qty), 18),
+    toDecimal128(toString(price), 18),
+    algorithm_name_placed,
+    toDecimal128(toString(lowest_sell_prc), 18),
+    toDecimal128(toString(highest_buy_prc), 18),
+    toDecimal128(toString(commission_quote_qty), 18),
+    time_placed
+FROM history_orders;
+
+EXCHANGE TABLES history_orders AND history_orders_decimal;
+DROP TABLE history_orders_decimal;
+
+CREATE TABLE order_book_snapshots_decimal (
+    exchange String,
+    pair String,
+    time DateTime64(6),
+    bid_prices Array(Decimal(38, 18)),
+    bid_qtys Array(Decimal(38, 18)),
+    ask_prices Array(Decimal(38, 18)),
+    ask_qtys Array(Decimal(38, 18)),
+    sequence Nullable(Int64)
+)
+ENGINE = MergeTree()
+ORDER BY (exchange, pair, time);
+
+INSERT INTO order_book_snapshots_decimal
+SELECT
+    exchange,
+    pair,
+    time,
+    arrayMap(x -> toDecimal128(toString(x), 18), bid_prices),
+    arrayMap(x -> toDecimal128(toString(x), 18), bid_qtys),
+    arrayMap(x -> toDecimal128(toString(x), 18), ask_prices),
+    arrayMap(x -> toDecimal128(toString(x), 18), ask_qtys),
+    sequence
+FROM order_book_snapshots;
+
+EXCHANGE TABLES order_book_snapshots AND order_book_snapshots_decimal;
+DROP TABLE order_book_snapshots_decimal;
@@ -0,0 +1,3 @@
+ALTER TABLE order_book_snapshots REMOVE TTL;
+
+ALTER TABLE order_book_snapshots DROP COLUMN IF EXISTS retention_hours;
@@ -0,0 +1,6 @@
+-- Every snapshot carries the retention of its exchange at the time it was saved,
+-- 0 keeps it forever. Expired parts are removed by ClickHouse during merges.
+ALTER TABLE order_book_snapshots ADD COLUMN IF NOT EXISTS retention_hours UInt32 DEFAULT 0;
+
+ALTER TABLE order_book_snapshots
+    MODIFY TTL toDateTime(time) + toIntervalHour(retention_hours) WHERE retention_hours > 0;
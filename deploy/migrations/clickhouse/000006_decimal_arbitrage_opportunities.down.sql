@@ -0,0 +1,4 @@
+ALTER TABLE arbitrage_opportunities
+    MODIFY COLUMN best_ask Float64,
+    MODIFY COLUMN best_bid Float64,
+    MODIFY COLUMN base_qty Float64;
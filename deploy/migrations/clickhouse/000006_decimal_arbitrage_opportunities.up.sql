@@ -0,0 +1,36 @@
+-- Prices and quantities taken from order book levels are stored exactly, fee adjusted
+-- amounts and the profit stay Float64. Float64 values are converted through their shortest
+-- text representation, so that 0.010782342 does not become 0.010782341999999999.
+CREATE TABLE arbitrage_opportunities_decimal (
+    pair String,
+    buy_exchange String,
+    sell_exchange String,
+    best_ask Decimal(38, 18),
+    best_bid Decimal(38, 18),
+    base_qty Decimal(38, 18),
+    buy_quote_qty Float64,
+    sell_quote_qty Float64,
+    profit Float64,
+    profit_bps Float64,
+    detected_at DateTime64(6)
+)
+ENGINE = MergeTree()
+ORDER BY (pair, detected_at);
+
+INSERT INTO arbitrage_opportunities_decimal
+SELECT
+    pair,
+    buy_exchange,
+    sell_exchange,
+    toDecimal128(toString(best_ask), 18),
+    toDecimal128(toString(best_bid), 18),
+    toDecimal128(toString(base_qty), 18),
+    buy_quote_qty,
+    sell_quote_qty,
+    profit,
+    profit_bps,
+    detected_at
+FROM arbitrage_opportunities;
+
+EXCHANGE TABLES arbitrage_opportunities AND arbitrage_opportunities_decimal;
+DROP TABLE arbitrage_opportunities_decimal;
@@ -0,0 +1,3 @@
+ALTER TABLE order_books
+    DROP COLUMN IF EXISTS resync_required,
+    DROP COLUMN IF EXISTS sequence;
@@ -0,0 +1,3 @@
+ALTER TABLE order_books
+    ADD COLUMN IF NOT EXISTS sequence BIGINT,
+    ADD COLUMN IF NOT EXISTS resync_required BOOLEAN NOT NULL DEFAULT FALSE;
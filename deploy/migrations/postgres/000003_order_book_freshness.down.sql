@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS order_books_updated_at_idx;
+ALTER TABLE order_books
+    DROP COLUMN IF EXISTS stale,
+    DROP COLUMN IF EXISTS exchange_time,
+    DROP COLUMN IF EXISTS updated_at;
\ No newline at end of file
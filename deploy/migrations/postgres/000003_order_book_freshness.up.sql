@@ -0,0 +1,5 @@
+ALTER TABLE order_books
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS exchange_time TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS stale BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS order_books_updated_at_idx ON order_books (updated_at);
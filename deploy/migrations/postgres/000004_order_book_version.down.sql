@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS order_books_notify_change ON order_books;
+DROP FUNCTION IF EXISTS notify_order_book_change();
+ALTER TABLE order_books
+    DROP COLUMN IF EXISTS version;
+DROP SEQUENCE IF EXISTS order_book_version_seq;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE SEQUENCE IF NOT EXISTS order_book_version_seq;
+
+ALTER TABLE order_books
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT nextval('order_book_version_seq');
+
+CREATE OR REPLACE FUNCTION notify_order_book_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('order_book_changes', json_build_object(
+            'exchange', OLD.exchange, 'pair', OLD.pair, 'version', OLD.version, 'deleted', TRUE)::text);
+        RETURN OLD;
+    END IF;
+    PERFORM pg_notify('order_book_changes', json_build_object(
+        'exchange', NEW.exchange, 'pair', NEW.pair, 'version', NEW.version, 'deleted', FALSE)::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER order_books_notify_change
+    AFTER INSERT OR DELETE OR UPDATE OF version ON order_books
+    FOR EACH ROW EXECUTE FUNCTION notify_order_book_change();
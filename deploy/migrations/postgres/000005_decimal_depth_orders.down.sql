@@ -0,0 +1,6 @@
+ALTER TYPE depth_order RENAME TO depth_order_numeric;
+CREATE TYPE depth_order AS (price FLOAT8, base_qty FLOAT8);
+ALTER TABLE order_books
+    ALTER COLUMN bids TYPE depth_order[] USING bids::text::depth_order[],
+    ALTER COLUMN asks TYPE depth_order[] USING asks::text::depth_order[];
+DROP TYPE depth_order_numeric;
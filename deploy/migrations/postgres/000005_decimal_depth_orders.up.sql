@@ -0,0 +1,9 @@
+-- Composite type attributes can not be altered while columns use the type, so the
+-- levels are converted to a new type through their text form. FLOAT8 is output as
+-- the shortest exact representation, so 0.010782342 is kept as 0.010782342.
+ALTER TYPE depth_order RENAME TO depth_order_float8;
+CREATE TYPE depth_order AS (price NUMERIC, base_qty NUMERIC);
+ALTER TABLE order_books
+    ALTER COLUMN bids TYPE depth_order[] USING bids::text::depth_order[],
+    ALTER COLUMN asks TYPE depth_order[] USING asks::text::depth_order[];
+DROP TYPE depth_order_float8;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS instruments (
+    exchange TEXT NOT NULL,
+    pair TEXT NOT NULL,
+    base TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    tick_size NUMERIC NOT NULL CHECK (tick_size > 0),
+    qty_step NUMERIC NOT NULL CHECK (qty_step > 0),
+    min_notional NUMERIC NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (exchange, pair)
+);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS exchanges (
+    name TEXT PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    taker_fee DOUBLE PRECISION CHECK (taker_fee >= 0 AND taker_fee < 1),
+    maker_fee DOUBLE PRECISION CHECK (maker_fee >= 0 AND maker_fee < 1),
+    validation_mode TEXT NOT NULL DEFAULT '' CHECK (validation_mode IN ('', 'strict', 'lenient')),
+    snapshot_retention_hours INTEGER NOT NULL DEFAULT 0 CHECK (snapshot_retention_hours >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- exchanges that already have data stay writable
+INSERT INTO exchanges (name, display_name)
+SELECT exchange, exchange FROM order_books
+UNION
+SELECT exchange, exchange FROM instruments
+ON CONFLICT (name) DO NOTHING;
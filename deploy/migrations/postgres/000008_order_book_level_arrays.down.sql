@@ -0,0 +1,19 @@
+CREATE TYPE depth_order AS (price NUMERIC, base_qty NUMERIC);
+
+ALTER TABLE order_books
+    ADD COLUMN bids depth_order[] NOT NULL DEFAULT '{}',
+    ADD COLUMN asks depth_order[] NOT NULL DEFAULT '{}';
+
+UPDATE order_books SET
+    bids = ARRAY(SELECT ROW(l.price, l.base_qty)::depth_order
+        FROM unnest(bid_prices, bid_qtys) WITH ORDINALITY AS l(price, base_qty, n) ORDER BY l.n),
+    asks = ARRAY(SELECT ROW(l.price, l.base_qty)::depth_order
+        FROM unnest(ask_prices, ask_qtys) WITH ORDINALITY AS l(price, base_qty, n) ORDER BY l.n);
+
+ALTER TABLE order_books
+    ALTER COLUMN bids DROP DEFAULT,
+    ALTER COLUMN asks DROP DEFAULT,
+    DROP COLUMN bid_prices,
+    DROP COLUMN bid_qtys,
+    DROP COLUMN ask_prices,
+    DROP COLUMN ask_qtys;
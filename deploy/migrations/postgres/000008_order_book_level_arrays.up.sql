@@ -0,0 +1,21 @@
+-- Levels are stored as parallel price and quantity arrays, so a side is written
+-- with two bind parameters however deep the book is.
+ALTER TABLE order_books
+    ADD COLUMN bid_prices NUMERIC[] NOT NULL DEFAULT '{}',
+    ADD COLUMN bid_qtys NUMERIC[] NOT NULL DEFAULT '{}',
+    ADD COLUMN ask_prices NUMERIC[] NOT NULL DEFAULT '{}',
+    ADD COLUMN ask_qtys NUMERIC[] NOT NULL DEFAULT '{}';
+
+UPDATE order_books SET
+    bid_prices = ARRAY(SELECT l.price FROM unnest(bids) WITH ORDINALITY AS l(price, base_qty, n) ORDER BY l.n),
+    bid_qtys = ARRAY(SELECT l.base_qty FROM unnest(bids) WITH ORDINALITY AS l(price, base_qty, n) ORDER BY l.n),
+    ask_prices = ARRAY(SELECT l.price FROM unnest(asks) WITH ORDINALITY AS l(price, base_qty, n) ORDER BY l.n),
+    ask_qtys = ARRAY(SELECT l.base_qty FROM unnest(asks) WITH ORDINALITY AS l(price, base_qty, n) ORDER BY l.n);
+
+ALTER TABLE order_books
+    DROP COLUMN bids,
+    DROP COLUMN asks,
+    ADD CONSTRAINT order_books_bid_levels_check CHECK (cardinality(bid_prices) = cardinality(bid_qtys)),
+    ADD CONSTRAINT order_books_ask_levels_check CHECK (cardinality(ask_prices) = cardinality(ask_qtys));
+
+DROP TYPE depth_order;
@@ -2,12 +2,20 @@ package app
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log/slog"
 	_ "market-info-storage/api/v1"
+	_ "market-info-storage/api/v2"
 	"market-info-storage/internal/config"
 	orderbookcontroller "market-info-storage/internal/controllers/v1/orderbook"
 	orderhistorycontroller "market-info-storage/internal/controllers/v1/orderhistory"
+	arbitragecontroller "market-info-storage/internal/controllers/v2/arbitrage"
+	discoverycontroller "market-info-storage/internal/controllers/v2/discovery"
+	eventscontroller "market-info-storage/internal/controllers/v2/events"
+	exchangecontroller "market-info-storage/internal/controllers/v2/exchange"
+	instrumentcontroller "market-info-storage/internal/controllers/v2/instrument"
+	orderbookcontrollerv2 "market-info-storage/internal/controllers/v2/orderbook"
 	"market-info-storage/internal/db/clickhouse"
 	"market-info-storage/internal/db/postgres"
 	"market-info-storage/internal/domain"
@@ -20,9 +28,11 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	sloggin "github.com/samber/slog-gin"
 	swaggerfiles "github.com/swaggo/files"
 	ginswagger "github.com/swaggo/gin-swagger"
+	"golang.org/x/net/webdav"
 )
 
 // @title           Market info storage
@@ -49,12 +59,88 @@ func Run(cfg config.Config) {
 
 	orderBookStorage := storages.NewOrderBookStorage(postgresClient)
 	historyOrderStorage := storages.NewHistoryOrderStorage(clickhouseClient)
+	orderBookSnapshotStorage := storages.NewOrderBookSnapshotStorage(clickhouseClient)
+	arbitrageOpportunityStorage := storages.NewArbitrageOpportunityStorage(clickhouseClient)
 
-	orderBookService := domain.NewOrderBookService(orderBookStorage)
-	orderHistoryService := domain.NewOrderHistoryService(historyOrderStorage)
+	instrumentStorage := storages.NewInstrumentStorage(postgresClient)
+	exchangeStorage := storages.NewExchangeStorage(postgresClient)
+
+	exchangeService := domain.NewExchangeService(exchangeStorage, cfg.Exchanges.RefreshInterval)
+	// writes of unregistered exchanges are rejected, so the service cannot start without the registry
+	err = exchangeService.Refresh()
+	if err != nil {
+		slog.Error("load exchanges", slogutils.ErrorAttr(err))
+		return
+	}
+	symbolNormalizer := domain.NewSymbolNormalizer(cfg.Symbols.QuoteAssets, cfg.Symbols.AssetAliases, cfg.Symbols.SymbolAliases)
+	if cfg.Symbols.MigrateStoredPairs {
+		// data saved before normalization was enabled is not found by canonical pairs otherwise
+		for _, storage := range []domain.StoredPairStorage{orderBookStorage, historyOrderStorage, orderBookSnapshotStorage} {
+			renamed, err := symbolNormalizer.MigrateStoredPairs(storage)
+			if err != nil {
+				slog.Error("migrate stored pairs", slogutils.ErrorAttr(err))
+				return
+			}
+			slog.Info("migrated stored pairs", slog.Int("renamed", renamed))
+		}
+	}
+	instrumentService := domain.NewInstrumentService(instrumentStorage, symbolNormalizer, exchangeService,
+		cfg.Instruments.RefreshInterval)
+	err = instrumentService.Refresh()
+	if err != nil {
+		slog.Error("load instruments", slogutils.ErrorAttr(err))
+	}
+	// a nil lookup disables validation against instruments
+	var instrumentValidation domain.InstrumentLookup
+	if cfg.Instruments.Validate {
+		instrumentValidation = instrumentService
+	}
+
+	orderBookValidator, err := newOrderBookValidator(cfg.OrderBookValidation, instrumentValidation, exchangeService)
+	if err != nil {
+		slog.Error("initialize order book validator", slogutils.ErrorAttr(err))
+		return
+	}
+
+	eventLog, err := domain.NewEventLog(cfg.Streaming.EventBufferSize)
+	if err != nil {
+		slog.Error("initialize event log", slogutils.ErrorAttr(err))
+		return
+	}
+	orderBookHub := domain.NewOrderBookHub(cfg.Streaming.BufferSize)
+	orderBookHub.AddListener(eventLog.PublishOrderBookUpdate)
+	var serviceOrderBookStorage domain.OrderBookStorage = orderBookStorage
+	orderBookChangeHandlers := []storages.OrderBookChangeHandler{}
+	if cfg.OrderBookCache.TTL > 0 && cfg.OrderBookCache.MaxEntries > 0 {
+		cachedOrderBookStorage := storages.NewCachedOrderBookStorage(orderBookStorage,
+			cfg.OrderBookCache.TTL, cfg.OrderBookCache.MaxEntries)
+		serviceOrderBookStorage = cachedOrderBookStorage
+		orderBookChangeHandlers = append(orderBookChangeHandlers, cachedOrderBookStorage)
+	}
+	var serviceOrderBookSnapshotStorage domain.OrderBookSnapshotStorage = orderBookSnapshotStorage
+	var bufferedOrderBookSnapshotStorage *storages.BufferedOrderBookSnapshotStorage
+	if cfg.OrderBookSnapshots.FlushInterval > 0 {
+		bufferedOrderBookSnapshotStorage = storages.NewBufferedOrderBookSnapshotStorage(orderBookSnapshotStorage,
+			cfg.OrderBookSnapshots.FlushInterval, cfg.OrderBookSnapshots.BatchSize)
+		serviceOrderBookSnapshotStorage = bufferedOrderBookSnapshotStorage
+	}
+	orderBookService := domain.NewOrderBookService(serviceOrderBookStorage, serviceOrderBookSnapshotStorage, orderBookValidator,
+		orderBookHub, symbolNormalizer, instrumentService, exchangeService)
+	orderHistoryService := domain.NewOrderHistoryService(historyOrderStorage, eventLog, symbolNormalizer,
+		instrumentValidation, exchangeService)
+	marketDiscoveryService := domain.NewMarketDiscoveryService(orderBookStorage, historyOrderStorage, symbolNormalizer)
+	arbitrageService := domain.NewArbitrageService(orderBookStorage, arbitrageOpportunityStorage,
+		domain.NewTakerFees(cfg.Arbitrage.DefaultTakerFee, cfg.Arbitrage.TakerFees, exchangeService), symbolNormalizer,
+		cfg.Arbitrage.MaxBookAge, cfg.Arbitrage.ScanDepth)
 
 	orderBookController := orderbookcontroller.NewOrderBookController(orderBookService)
 	orderHistoryController := orderhistorycontroller.NewOrderHistoryController(orderHistoryService)
+	orderBookControllerV2 := orderbookcontrollerv2.NewOrderBookController(orderBookService)
+	arbitrageController := arbitragecontroller.NewArbitrageController(arbitrageService)
+	discoveryController := discoverycontroller.NewDiscoveryController(marketDiscoveryService)
+	eventsController := eventscontroller.NewEventsController(eventLog)
+	instrumentController := instrumentcontroller.NewInstrumentController(instrumentService)
+	exchangeController := exchangecontroller.NewExchangeController(exchangeService)
 
 	switch cfg.Env {
 	case config.EnvLocal:
@@ -66,14 +152,47 @@ func Run(cfg config.Config) {
 	engine.Use(sloggin.New(logger))
 	engine.Use(gin.Recovery())
 	engine.GET("api/v1/swagger/*any", ginswagger.WrapHandler(swaggerfiles.Handler))
+	// the handler serving swagger UI files keeps the path prefix of its first request, so v2 needs its own
+	swaggerFilesV2 := &webdav.Handler{FileSystem: swaggerfiles.FS, LockSystem: webdav.NewMemLS()}
+	engine.GET("api/v2/swagger/*any", ginswagger.WrapHandler(swaggerFilesV2, ginswagger.InstanceName("v2")))
+	engine.GET("debug/vars", gin.WrapH(expvar.Handler()))
 	orderBookController.RegisterRoutes(engine)
 	orderHistoryController.RegisterRoutes(engine)
+	orderBookControllerV2.RegisterRoutes(engine)
+	arbitrageController.RegisterRoutes(engine)
+	discoveryController.RegisterRoutes(engine)
+	eventsController.RegisterRoutes(engine)
+	instrumentController.RegisterRoutes(engine)
+	exchangeController.RegisterRoutes(engine)
 
 	srv := &http.Server{
 		Addr:    cfg.HTTPServer.IpAddress + ":" + cfg.HTTPServer.Port,
 		Handler: engine.Handler(),
 	}
 
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	orderBookSweeper := domain.NewOrderBookSweeper(orderBookStorage, cfg.OrderBookFreshness.SweepInterval,
+		cfg.OrderBookFreshness.StaleAfter, cfg.OrderBookFreshness.ExpireAfter)
+	go orderBookSweeper.Run(backgroundCtx)
+	go instrumentService.Run(backgroundCtx)
+	go exchangeService.Run(backgroundCtx)
+	if bufferedOrderBookSnapshotStorage != nil {
+		go bufferedOrderBookSnapshotStorage.Run(backgroundCtx)
+	}
+
+	orderBookChangeListener := storages.NewOrderBookChangeListener(postgres.NewListener(
+		cfg.Postgres, 10*time.Second, time.Minute, logListenerEvent))
+	orderBookFanout := domain.NewOrderBookFanout(orderBookStorage, orderBookHub)
+	// the cache drops changed books before the fanout publishes them
+	orderBookChangeHandlers = append(orderBookChangeHandlers, orderBookFanout)
+	go func() {
+		err := orderBookChangeListener.Listen(backgroundCtx, orderBookChangeHandlers...)
+		if err != nil {
+			slog.Error("listen to order book changes", slogutils.ErrorAttr(err))
+		}
+	}()
+
 	slog.Info("Starting server ...")
 
 	go func() {
@@ -94,6 +213,10 @@ func Run(cfg config.Config) {
 		slog.Error("Server Shutdown:", slogutils.ErrorAttr(err))
 		os.Exit(1)
 	}
+	// snapshots of the last handled requests are still pending
+	if bufferedOrderBookSnapshotStorage != nil {
+		bufferedOrderBookSnapshotStorage.Flush()
+	}
 
 	select {
 	case <-ctx.Done():
@@ -102,6 +225,35 @@ func Run(cfg config.Config) {
 	slog.Info("Server exiting")
 }
 
+func newOrderBookValidator(
+	cfg config.OrderBookValidationConfig,
+	instruments domain.InstrumentLookup,
+	exchanges domain.ExchangeLookup,
+) (*domain.OrderBookValidator, error) {
+	defaultMode, err := domain.ParseValidationMode(cfg.DefaultMode)
+	if err != nil {
+		return nil, err
+	}
+	exchangeModes := make(map[string]domain.ValidationMode, len(cfg.ExchangeModes))
+	for exchangeName, mode := range cfg.ExchangeModes {
+		exchangeModes[exchangeName], err = domain.ParseValidationMode(mode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.NewOrderBookValidator(defaultMode, exchangeModes, instruments, exchanges), nil
+}
+
+func logListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventConnectionAttemptFailed, pq.ListenerEventDisconnected:
+		slog.Error("order book change listener connection", slogutils.ErrorAttr(err))
+	case pq.ListenerEventReconnected:
+		slog.Info("order book change listener reconnected")
+	}
+}
+
 func mustNewLogger(env config.Env) (logger *slog.Logger) {
 	switch env {
 	case config.EnvLocal:
@@ -21,6 +21,16 @@ type Config struct {
 	ClickHouse DBConfig         `env-prefix:"CLICKHOUSE_"`
 	Postgres   DBConfig         `env-prefix:"POSTGRES_"`
 	HTTPServer HTTPServerConfig `env-prefix:"HTTP_SERVER_"`
+
+	OrderBookValidation OrderBookValidationConfig `env-prefix:"ORDER_BOOK_VALIDATION_"`
+	OrderBookFreshness  OrderBookFreshnessConfig  `env-prefix:"ORDER_BOOK_FRESHNESS_"`
+	Arbitrage           ArbitrageConfig           `env-prefix:"ARBITRAGE_"`
+	Streaming           StreamingConfig           `env-prefix:"STREAMING_"`
+	OrderBookCache      OrderBookCacheConfig      `env-prefix:"ORDER_BOOK_CACHE_"`
+	OrderBookSnapshots  OrderBookSnapshotsConfig  `env-prefix:"ORDER_BOOK_SNAPSHOTS_"`
+	Symbols             SymbolsConfig             `env-prefix:"SYMBOLS_"`
+	Instruments         InstrumentsConfig         `env-prefix:"INSTRUMENTS_"`
+	Exchanges           ExchangesConfig           `env-prefix:"EXCHANGES_"`
 }
 
 type HTTPServerConfig struct {
@@ -38,6 +48,79 @@ type DBConfig struct {
 	SSLMode  string `env:"SSL_MODE"`
 }
 
+// OrderBookValidationConfig holds validation modes ("strict" or "lenient").
+// ExchangeModes is set as "exchange:mode,exchange:mode".
+type OrderBookValidationConfig struct {
+	DefaultMode   string            `env:"DEFAULT_MODE" env-default:"strict"`
+	ExchangeModes map[string]string `env:"EXCHANGE_MODES"`
+}
+
+// OrderBookFreshnessConfig sets how often and when books that stopped updating are flagged
+// as stale and deleted, 0 disables the respective step or the sweep altogether.
+type OrderBookFreshnessConfig struct {
+	SweepInterval time.Duration `env:"SWEEP_INTERVAL" env-default:"10s"`
+	StaleAfter    time.Duration `env:"STALE_AFTER" env-default:"1m"`
+	ExpireAfter   time.Duration `env:"EXPIRE_AFTER" env-default:"0"`
+}
+
+// ArbitrageConfig holds taker fee rates, for example 0.001 for 0.1%.
+// TakerFees is set as "exchange:fee,exchange:fee". Books flagged stale or updated longer
+// than MaxBookAge ago are not scanned, and only the top ScanDepth levels of each side are
+// walked, 0 disables the respective limit.
+type ArbitrageConfig struct {
+	DefaultTakerFee float64            `env:"DEFAULT_TAKER_FEE" env-default:"0.001"`
+	TakerFees       map[string]float64 `env:"TAKER_FEES"`
+	MaxBookAge      time.Duration      `env:"MAX_BOOK_AGE" env-default:"30s"`
+	ScanDepth       int                `env:"SCAN_DEPTH" env-default:"100"`
+}
+
+// StreamingConfig sets how many updates are queued for a WebSocket subscriber before a subscriber
+// without conflation is disconnected, and how many of the latest events are kept for resuming event feeds.
+type StreamingConfig struct {
+	BufferSize      int `env:"BUFFER_SIZE" env-default:"256"`
+	EventBufferSize int `env:"EVENT_BUFFER_SIZE" env-default:"10000"`
+}
+
+// OrderBookCacheConfig sets how long an order book is served from memory and how
+// many books are kept there, a TTL of 0 disables the cache.
+type OrderBookCacheConfig struct {
+	TTL        time.Duration `env:"TTL" env-default:"1s"`
+	MaxEntries int           `env:"MAX_ENTRIES" env-default:"10000"`
+}
+
+// OrderBookSnapshotsConfig sets how snapshots are batched before they are written to ClickHouse,
+// pending snapshots are written every FlushInterval or once there are BatchSize of them.
+// A FlushInterval of 0 writes every snapshot with the request that saved the book.
+type OrderBookSnapshotsConfig struct {
+	FlushInterval time.Duration `env:"FLUSH_INTERVAL" env-default:"1s"`
+	BatchSize     int           `env:"BATCH_SIZE" env-default:"1000"`
+}
+
+// SymbolsConfig sets how exchange symbols are normalized to BASE_QUOTE. QuoteAssets split symbols without
+// a separator such as BTCUSDT. AssetAliases are set as "XBT:BTC,kraken/XDG:DOGE" and SymbolAliases
+// as "kraken/XXBTZUSD:BTC_USD", keys prefixed with an exchange name apply to that exchange only.
+// MigrateStoredPairs renames pairs stored under exchange symbols to the canonical form at startup.
+type SymbolsConfig struct {
+	QuoteAssets        []string          `env:"QUOTE_ASSETS" env-default:"USDT,USDC,FDUSD,BUSD,TUSD,DAI,EUR,USD,TRY,BTC,ETH,BNB"`
+	AssetAliases       map[string]string `env:"ASSET_ALIASES" env-default:"XBT:BTC"`
+	SymbolAliases      map[string]string `env:"SYMBOL_ALIASES"`
+	MigrateStoredPairs bool              `env:"MIGRATE_STORED_PAIRS" env-default:"false"`
+}
+
+// InstrumentsConfig sets whether order books and history orders of pairs with a registered instrument
+// are validated against it and how often instruments changed through other instances are picked up,
+// a RefreshInterval of 0 loads them only at startup.
+type InstrumentsConfig struct {
+	Validate        bool          `env:"VALIDATE" env-default:"false"`
+	RefreshInterval time.Duration `env:"REFRESH_INTERVAL" env-default:"1m"`
+}
+
+// ExchangesConfig sets how often registry changes made through other instances are picked up,
+// a RefreshInterval of 0 loads the registry only at startup.
+type ExchangesConfig struct {
+	RefreshInterval time.Duration `env:"REFRESH_INTERVAL" env-default:"1m"`
+}
+
 var (
 	once sync.Once
 	cfg  Config
@@ -1,6 +1,7 @@
 package httputils
 
 import (
+	"market-info-storage/internal/domain"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +12,11 @@ type HTTPError struct {
 	Message string `json:"error,omitempty"`
 }
 
+type HTTPValidationError struct {
+	Message    string                  `json:"error,omitempty"`
+	Violations []domain.LevelViolation `json:"violations"`
+}
+
 func Error(ctx *gin.Context, status int, err error) {
 	ctx.JSON(status, HTTPError{Message: err.Error()})
 }
@@ -35,6 +41,29 @@ func NotFoundError(ctx *gin.Context, err error) {
 	Error(ctx, http.StatusNotFound, err)
 }
 
+func ForbiddenError(ctx *gin.Context, err error) {
+	Error(ctx, http.StatusForbidden, err)
+}
+
+func ConflictError(ctx *gin.Context, err error) {
+	Error(ctx, http.StatusConflict, err)
+}
+
+func PreconditionFailedError(ctx *gin.Context, err error) {
+	Error(ctx, http.StatusPreconditionFailed, err)
+}
+
+func UnprocessableEntityError(ctx *gin.Context, err error) {
+	Error(ctx, http.StatusUnprocessableEntity, err)
+}
+
+func OrderBookInvalidError(ctx *gin.Context, err domain.OrderBookInvalid) {
+	ctx.JSON(http.StatusUnprocessableEntity, HTTPValidationError{
+		Message:    err.Error(),
+		Violations: err.Violations,
+	})
+}
+
 func InternalError(ctx *gin.Context) {
 	Error(ctx, http.StatusInternalServerError, errors.New(""))
 }
@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -21,8 +22,8 @@ func TestSaveOrderBook(t *testing.T) {
 	exchange := "bybit"
 	pair := "MATIC_USDT"
 	orderBook := []domain.DepthOrder{
-		{Price: 0.53, BaseQty: 1.5},
-		{Price: 0.54, BaseQty: 1.1},
+		{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")},
+		{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")},
 	}
 
 	service := mocks.NewOrderBookService(t)
@@ -102,9 +103,9 @@ func TestSaveOrderBookWithOddNumberOfOrders(t *testing.T) {
 	exchange := "bybit"
 	pair := "MATIC_USDT"
 	orderBook := []domain.DepthOrder{
-		{Price: 0.53, BaseQty: 1.5},
-		{Price: 0.54, BaseQty: 1.1},
-		{Price: 0.55, BaseQty: 0.9},
+		{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")},
+		{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")},
+		{Price: decimal.RequireFromString("0.55"), BaseQty: decimal.RequireFromString("0.9")},
 	}
 
 	service := mocks.NewOrderBookService(t)
@@ -128,8 +129,8 @@ func TestGetOrderBook(t *testing.T) {
 	exchange := "binance"
 	pair := "SOL_USDT"
 	orderBook := []domain.DepthOrder{
-		{Price: 0.53, BaseQty: 1.5},
-		{Price: 0.54, BaseQty: 1.1},
+		{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")},
+		{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")},
 	}
 
 	service := mocks.NewOrderBookService(t)
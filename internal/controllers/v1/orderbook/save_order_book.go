@@ -19,7 +19,7 @@ type saveOrderBookRequestBody struct {
 
 // saveOrderBook godoc
 // @Summary Save Order Book
-// @Description Saves an order book for a specific exchange and pair.
+// @Description Saves an order book for a specific exchange and pair, the exchange must be registered and enabled.
 // @Tags OrderBook
 // @Accept json
 // @Param exchange path string true "Exchange name"
@@ -27,6 +27,9 @@ type saveOrderBookRequestBody struct {
 // @Param orderBook body saveOrderBookRequestBody true "Order Book data"
 // @Success 200
 // @Failure 400 {object} httputils.HTTPError "Invalid request body"
+// @Failure 403 {object} httputils.HTTPError "Exchange is disabled"
+// @Failure 404 {object} httputils.HTTPError "Exchange is not registered"
+// @Failure 422 {object} httputils.HTTPValidationError "Order Book violates invariants"
 // @Failure 500 {object} httputils.HTTPError "Internal server error"
 // @Router /exchanges/{exchange}/pairs/{pair}/order-book [put]
 func (c *OrderBookController) saveOrderBook(ctx *gin.Context) {
@@ -49,7 +52,18 @@ func (c *OrderBookController) saveOrderBook(ctx *gin.Context) {
 	}
 
 	err = c.orderBookService.SaveOrderBook(reqURI.ExchangeName, reqURI.Pair, reqBody.OrderBook)
-	if err != nil {
+	switch err := err.(type) {
+	case nil:
+	case domain.ExchangeNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	case domain.ExchangeDisabled:
+		httputils.ForbiddenError(ctx, err)
+		return
+	case domain.OrderBookInvalid:
+		httputils.OrderBookInvalidError(ctx, err)
+		return
+	default:
 		httputils.InternalError(ctx)
 		return
 	}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -20,12 +21,12 @@ func TestSaveHistoryOrder(t *testing.T) {
 	historyOrder := newHistoryOrderToSave()
 	historyOrder.Side = "buy"
 	historyOrder.Type = "market"
-	*historyOrder.BaseQty = 10.0
-	*historyOrder.Price = 100.0
+	*historyOrder.BaseQty = decimal.RequireFromString("10")
+	*historyOrder.Price = decimal.RequireFromString("100")
 	historyOrder.AlgorithmNamePlaced = "MyAlgorithm"
-	*historyOrder.LowestSellPrc = 99.0
-	*historyOrder.HighestBuyPrc = 101.0
-	*historyOrder.CommissionQuoteQty = 0.1
+	*historyOrder.LowestSellPrc = decimal.RequireFromString("99")
+	*historyOrder.HighestBuyPrc = decimal.RequireFromString("101")
+	*historyOrder.CommissionQuoteQty = decimal.RequireFromString("0.1")
 	historyOrder.TimePlaced = time.Now().Truncate(time.Nanosecond)
 	reqQuery := saveOrderRequestQuery{
 		ClientName:   "John Doe",
@@ -71,6 +72,55 @@ func TestSaveHistoryOrder(t *testing.T) {
 	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
 }
 
+func TestSaveHistoryOrderOffTick(t *testing.T) {
+	historyOrder := newHistoryOrderToSave()
+	historyOrder.Side = "buy"
+	historyOrder.Type = "limit"
+	*historyOrder.BaseQty = decimal.RequireFromString("1")
+	*historyOrder.Price = decimal.RequireFromString("100.005")
+	historyOrder.AlgorithmNamePlaced = "MyAlgorithm"
+	*historyOrder.LowestSellPrc = decimal.RequireFromString("99")
+	*historyOrder.HighestBuyPrc = decimal.RequireFromString("101")
+	*historyOrder.CommissionQuoteQty = decimal.RequireFromString("0.1")
+	historyOrder.TimePlaced = time.Now().Truncate(time.Nanosecond)
+
+	service := mocks.NewOrderHistoryService(t)
+	service.On("SaveHistoryOrder", &domain.HistoryOrder{
+		ClientName:          "John Doe",
+		ExchangeName:        "binance",
+		Label:               "My Order",
+		Pair:                "BTC_USDT",
+		Side:                historyOrder.Side,
+		Type:                historyOrder.Type,
+		BaseQty:             *historyOrder.BaseQty,
+		Price:               *historyOrder.Price,
+		AlgorithmNamePlaced: historyOrder.AlgorithmNamePlaced,
+		LowestSellPrc:       *historyOrder.LowestSellPrc,
+		HighestBuyPrc:       *historyOrder.HighestBuyPrc,
+		CommissionQuoteQty:  *historyOrder.CommissionQuoteQty,
+		TimePlaced:          historyOrder.TimePlaced,
+	}).Return(domain.HistoryOrderInvalid{Message: "price 100.005 is not a multiple of tick size 0.01"})
+	controller := NewOrderHistoryController(service)
+
+	reqBodyReader := new(bytes.Buffer)
+	err := json.NewEncoder(reqBodyReader).Encode(saveOrderRequestBody{HistoryOrder: *historyOrder})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/order-history", reqBodyReader)
+	q := req.URL.Query()
+	q.Add("client-name", "John Doe")
+	q.Add("exchange", "binance")
+	q.Add("label", "My Order")
+	q.Add("pair", "BTC_USDT")
+	req.URL.RawQuery = q.Encode()
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
 func TestGetOrderHistory(t *testing.T) {
 	client := domain.Client{
 		ClientName:   "John Doe",
@@ -86,12 +136,12 @@ func TestGetOrderHistory(t *testing.T) {
 			Pair:                client.Pair,
 			Side:                "buy",
 			Type:                "market",
-			BaseQty:             10.0,
-			Price:               100.0,
+			BaseQty:             decimal.RequireFromString("10"),
+			Price:               decimal.RequireFromString("100"),
 			AlgorithmNamePlaced: "MyAlgorithm",
-			LowestSellPrc:       99.0,
-			HighestBuyPrc:       101.0,
-			CommissionQuoteQty:  0.1,
+			LowestSellPrc:       decimal.RequireFromString("99"),
+			HighestBuyPrc:       decimal.RequireFromString("101"),
+			CommissionQuoteQty:  decimal.RequireFromString("0.1"),
 			TimePlaced:          time.Now().Truncate(time.Nanosecond),
 		},
 		{
@@ -101,12 +151,12 @@ func TestGetOrderHistory(t *testing.T) {
 			Pair:                client.Pair,
 			Side:                "sell",
 			Type:                "limit",
-			BaseQty:             99.0,
-			Price:               900.0,
+			BaseQty:             decimal.RequireFromString("99"),
+			Price:               decimal.RequireFromString("900"),
 			AlgorithmNamePlaced: "OtherAlgorithm",
-			LowestSellPrc:       99.0,
-			HighestBuyPrc:       901.0,
-			CommissionQuoteQty:  0.9,
+			LowestSellPrc:       decimal.RequireFromString("99"),
+			HighestBuyPrc:       decimal.RequireFromString("901"),
+			CommissionQuoteQty:  decimal.RequireFromString("0.9"),
 			TimePlaced:          time.Now().Truncate(time.Nanosecond),
 		},
 	}
@@ -142,10 +192,10 @@ func TestGetOrderHistory(t *testing.T) {
 
 func newHistoryOrderToSave() *HistoryOrderToSave {
 	return &HistoryOrderToSave{
-		BaseQty:            new(float64),
-		Price:              new(float64),
-		LowestSellPrc:      new(float64),
-		HighestBuyPrc:      new(float64),
-		CommissionQuoteQty: new(float64),
+		BaseQty:            new(decimal.Decimal),
+		Price:              new(decimal.Decimal),
+		LowestSellPrc:      new(decimal.Decimal),
+		HighestBuyPrc:      new(decimal.Decimal),
+		CommissionQuoteQty: new(decimal.Decimal),
 	}
 }
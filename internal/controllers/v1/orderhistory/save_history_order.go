@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 )
 
 type saveOrderRequestBody struct {
@@ -20,20 +21,20 @@ type saveOrderRequestQuery struct {
 }
 
 type HistoryOrderToSave struct {
-	Side                string    `json:"side" binding:"required"`
-	Type                string    `json:"type" binding:"required"`
-	BaseQty             *float64  `json:"baseQty" binding:"required"`
-	Price               *float64  `json:"price" binding:"required"`
-	AlgorithmNamePlaced string    `json:"algorithmNamePlaced" binding:"required"`
-	LowestSellPrc       *float64  `json:"lowestSellPrc" binding:"required"`
-	HighestBuyPrc       *float64  `json:"highestBuyPrc" binding:"required"`
-	CommissionQuoteQty  *float64  `json:"commissionQuoteQty" binding:"required"`
-	TimePlaced          time.Time `json:"timePlaced" binding:"required"`
+	Side                string           `json:"side" binding:"required"`
+	Type                string           `json:"type" binding:"required"`
+	BaseQty             *decimal.Decimal `json:"baseQty" binding:"required" swaggertype:"string"`
+	Price               *decimal.Decimal `json:"price" binding:"required" swaggertype:"string"`
+	AlgorithmNamePlaced string           `json:"algorithmNamePlaced" binding:"required"`
+	LowestSellPrc       *decimal.Decimal `json:"lowestSellPrc" binding:"required" swaggertype:"string"`
+	HighestBuyPrc       *decimal.Decimal `json:"highestBuyPrc" binding:"required" swaggertype:"string"`
+	CommissionQuoteQty  *decimal.Decimal `json:"commissionQuoteQty" binding:"required" swaggertype:"string"`
+	TimePlaced          time.Time        `json:"timePlaced" binding:"required"`
 }
 
 // saveOrder godoc
 // @Summary Save order
-// @Description Saves an order.
+// @Description Saves an order. With instrument validation enabled an order of a pair with a registered instrument is rejected if its price is off tick, its quantity off step or its notional below the minimum.
 // @Tags OrderHistory
 // @Accept json
 // @Param client-name query string true "Client name"
@@ -43,6 +44,9 @@ type HistoryOrderToSave struct {
 // @Param history-order body saveOrderRequestBody true "History order"
 // @Success 200
 // @Failure 400 {object} httputils.HTTPError "Invalid request body"
+// @Failure 403 {object} httputils.HTTPError "Exchange is disabled"
+// @Failure 404 {object} httputils.HTTPError "Exchange is not registered"
+// @Failure 422 {object} httputils.HTTPError "Order breaks the trading rules of the pair's instrument"
 // @Failure 500 {object} httputils.HTTPError "Internal server error"
 // @Router /order-history [post]
 func (c *OrderHistoryController) saveHistoryOrder(ctx *gin.Context) {
@@ -74,7 +78,18 @@ func (c *OrderHistoryController) saveHistoryOrder(ctx *gin.Context) {
 		TimePlaced:          reqBody.HistoryOrder.TimePlaced,
 	}
 	err = c.orderHistoryService.SaveHistoryOrder(historyOrder)
-	if err != nil {
+	switch err.(type) {
+	case nil:
+	case domain.ExchangeNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	case domain.ExchangeDisabled:
+		httputils.ForbiddenError(ctx, err)
+		return
+	case domain.HistoryOrderInvalid:
+		httputils.UnprocessableEntityError(ctx, err)
+		return
+	default:
 		httputils.InternalError(ctx)
 		return
 	}
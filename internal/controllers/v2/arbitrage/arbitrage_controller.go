@@ -0,0 +1,28 @@
+package arbitragecontroller
+
+import (
+	"market-info-storage/internal/controllers"
+	"market-info-storage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ArbitrageController struct {
+	arbitrageService ArbitrageService
+}
+
+//go:generate mockery --name ArbitrageService --filename arbitrage_service.go
+type ArbitrageService interface {
+	FindArbitrageOpportunities(pairs, exchangeNames []string, record bool) ([]domain.ArbitrageOpportunity, error)
+}
+
+func NewArbitrageController(arbitrageService ArbitrageService) controllers.Controller {
+	return &ArbitrageController{
+		arbitrageService: arbitrageService,
+	}
+}
+
+func (c *ArbitrageController) RegisterRoutes(engine *gin.Engine) {
+	arbitrageGroup := engine.Group("/api/v2/arbitrage")
+	arbitrageGroup.GET("/opportunities", c.findArbitrageOpportunities)
+}
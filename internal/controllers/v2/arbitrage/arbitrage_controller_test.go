@@ -0,0 +1,99 @@
+package arbitragecontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"market-info-storage/internal/controllers/v2/arbitrage/mocks"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindArbitrageOpportunities(t *testing.T) {
+	pairs := []string{"BTC_USDT"}
+	exchanges := []string{"binance", "bybit"}
+	opportunities := domain.FindArbitrageOpportunities("BTC_USDT", map[string]*domain.OrderBook{
+		"binance": {
+			Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("99"), BaseQty: decimal.RequireFromString("1")}},
+			Asks: []domain.DepthOrder{{Price: decimal.RequireFromString("100"), BaseQty: decimal.RequireFromString("1")}, {Price: decimal.RequireFromString("101"), BaseQty: decimal.RequireFromString("1")}},
+		},
+		"bybit": {
+			Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("102"), BaseQty: decimal.RequireFromString("1.5")}, {Price: decimal.RequireFromString("100.5"), BaseQty: decimal.RequireFromString("1")}},
+			Asks: []domain.DepthOrder{{Price: decimal.RequireFromString("103"), BaseQty: decimal.RequireFromString("1")}},
+		},
+	}, domain.NewTakerFees(0, nil, nil), time.Now())
+
+	service := mocks.NewArbitrageService(t)
+	service.On("FindArbitrageOpportunities", pairs, exchanges, true).Return(opportunities, nil)
+	controller := NewArbitrageController(service)
+
+	url := "/api/v2/arbitrage/opportunities?pair=BTC_USDT&exchange=binance&exchange=bybit&record=true"
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody findArbitrageOpportunitiesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Len(t, respBody.Opportunities, 1)
+	opportunity := respBody.Opportunities[0]
+	require.Equal(t, "binance", opportunity.BuyExchange)
+	require.Equal(t, "bybit", opportunity.SellExchange)
+	require.Contains(t, w.Body.String(), `"bestAsk":"100","bestBid":"102","baseQty":"1.5"`)
+	require.True(t, decimal.RequireFromString("1.5").Equal(opportunity.BaseQty))
+	require.Equal(t, 150.5, opportunity.BuyQuoteQty)
+	require.Equal(t, 153.0, opportunity.SellQuoteQty)
+	require.Equal(t, 2.5, opportunity.Profit)
+}
+
+func TestFindArbitrageOpportunitiesWithFees(t *testing.T) {
+	opportunities := domain.FindArbitrageOpportunities("BTC_USDT", map[string]*domain.OrderBook{
+		"binance": {
+			Asks: []domain.DepthOrder{{Price: decimal.RequireFromString("100"), BaseQty: decimal.RequireFromString("1")}},
+		},
+		"bybit": {
+			Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("100.1"), BaseQty: decimal.RequireFromString("1")}},
+		},
+	}, domain.NewTakerFees(0.001, nil, nil), time.Now())
+
+	service := mocks.NewArbitrageService(t)
+	service.On("FindArbitrageOpportunities", []string(nil), []string(nil), false).Return(opportunities, nil)
+	controller := NewArbitrageController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/arbitrage/opportunities", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody findArbitrageOpportunitiesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Empty(t, respBody.Opportunities)
+}
+
+func TestFindArbitrageOpportunitiesWrongQuery(t *testing.T) {
+	service := mocks.NewArbitrageService(t)
+	controller := NewArbitrageController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/arbitrage/opportunities?record=maybe", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
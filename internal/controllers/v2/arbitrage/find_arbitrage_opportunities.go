@@ -0,0 +1,53 @@
+package arbitragecontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type findArbitrageOpportunitiesRequestQuery struct {
+	Pairs         []string `form:"pair"`
+	ExchangeNames []string `form:"exchange"`
+	Record        bool     `form:"record"`
+}
+
+type findArbitrageOpportunitiesResponse struct {
+	Opportunities []domain.ArbitrageOpportunity `json:"opportunities"`
+}
+
+// findArbitrageOpportunities godoc
+// @Summary Find Arbitrage Opportunities
+// @Description Lists pairs whose best bid on one exchange exceeds the best ask on another after taker fees, using the latest stored order books. Stale books are skipped. Size and profit are calculated by walking the top levels of both books. Opportunities are sorted by profit in descending order.
+// @Tags Arbitrage
+// @Produce json
+// @Param pair query []string false "Currency Pairs, all pairs if none is chosen" collectionFormat(multi)
+// @Param exchange query []string false "Exchange names, all exchanges if none is chosen" collectionFormat(multi)
+// @Param record query bool false "Save detected opportunities for later review"
+// @Success 200 {object} findArbitrageOpportunitiesResponse "Arbitrage Opportunities"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /arbitrage/opportunities [get]
+func (c *ArbitrageController) findArbitrageOpportunities(ctx *gin.Context) {
+	var reqQuery findArbitrageOpportunitiesRequestQuery
+	err := ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	opportunities, err := c.arbitrageService.FindArbitrageOpportunities(reqQuery.Pairs, reqQuery.ExchangeNames, reqQuery.Record)
+	if err != nil {
+		httputils.InternalError(ctx)
+		return
+	}
+	if opportunities == nil {
+		opportunities = []domain.ArbitrageOpportunity{}
+	}
+
+	ctx.JSON(http.StatusOK, findArbitrageOpportunitiesResponse{
+		Opportunities: opportunities,
+	})
+}
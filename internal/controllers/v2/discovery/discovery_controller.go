@@ -0,0 +1,30 @@
+package discoverycontroller
+
+import (
+	"market-info-storage/internal/controllers"
+	"market-info-storage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DiscoveryController struct {
+	marketDiscoveryService MarketDiscoveryService
+}
+
+//go:generate mockery --name MarketDiscoveryService --filename market_discovery_service.go
+type MarketDiscoveryService interface {
+	GetExchanges(filter domain.AssetFilter) ([]domain.ExchangeSummary, error)
+	GetExchangePairs(exchangeName string, filter domain.AssetFilter) ([]domain.PairSummary, error)
+}
+
+func NewDiscoveryController(marketDiscoveryService MarketDiscoveryService) controllers.Controller {
+	return &DiscoveryController{
+		marketDiscoveryService: marketDiscoveryService,
+	}
+}
+
+func (c *DiscoveryController) RegisterRoutes(engine *gin.Engine) {
+	exchangesGroup := engine.Group("/api/v2/exchanges")
+	exchangesGroup.GET("", c.getExchanges)
+	exchangesGroup.GET("/:exchange/pairs", c.getExchangePairs)
+}
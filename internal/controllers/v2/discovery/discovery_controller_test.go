@@ -0,0 +1,85 @@
+package discoverycontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"market-info-storage/internal/controllers/v2/discovery/mocks"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetExchanges(t *testing.T) {
+	lastUpdatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	exchanges := []domain.ExchangeSummary{
+		{Name: "binance", PairCount: 2, OrderBookCount: 1, LastUpdatedAt: &lastUpdatedAt},
+		{Name: "bybit", PairCount: 1},
+	}
+
+	service := mocks.NewMarketDiscoveryService(t)
+	service.On("GetExchanges", domain.AssetFilter{Quote: "USDT"}).Return(exchanges, nil)
+	controller := NewDiscoveryController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges?quote=USDT", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getExchangesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, exchanges, respBody.Exchanges)
+}
+
+func TestGetExchangePairs(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	pairs := []domain.PairSummary{
+		{
+			Pair:      "SOL_USDT",
+			Base:      "SOL",
+			Quote:     "USDT",
+			OrderBook: &domain.OrderBookSummary{UpdatedAt: updatedAt, BidLevels: 20, AskLevels: 18},
+		},
+	}
+
+	service := mocks.NewMarketDiscoveryService(t)
+	service.On("GetExchangePairs", "binance", domain.AssetFilter{Base: "SOL"}).Return(pairs, nil)
+	controller := NewDiscoveryController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs?base=SOL", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getExchangePairsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, pairs, respBody.Pairs)
+}
+
+func TestGetPairsOfUnknownExchange(t *testing.T) {
+	service := mocks.NewMarketDiscoveryService(t)
+	service.On("GetExchangePairs", "unknown", domain.AssetFilter{}).
+		Return(nil, domain.ExchangeNotFound{Message: "exchange not found"})
+	controller := NewDiscoveryController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/unknown/pairs", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
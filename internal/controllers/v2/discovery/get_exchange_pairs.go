@@ -0,0 +1,68 @@
+package discoverycontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getExchangePairsRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+}
+
+type getExchangePairsRequestQuery struct {
+	Base  string `form:"base"`
+	Quote string `form:"quote"`
+}
+
+type getExchangePairsResponse struct {
+	Pairs []domain.PairSummary `json:"pairs"`
+}
+
+// getExchangePairs godoc
+// @Summary Get Exchange Pairs
+// @Description Lists pairs of the exchange with stored order books or history orders, with the update time and level counts of the order book and the number of history orders. Pairs that can not be split into base and quote assets do not match base and quote filters.
+// @Tags Discovery
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Param base query string false "Base asset"
+// @Param quote query string false "Quote asset"
+// @Success 200 {object} getExchangePairsResponse "Pairs"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "Exchange not found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/pairs [get]
+func (c *DiscoveryController) getExchangePairs(ctx *gin.Context) {
+	var req getExchangePairsRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqQuery getExchangePairsRequestQuery
+	err = ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	pairs, err := c.marketDiscoveryService.GetExchangePairs(req.ExchangeName, domain.AssetFilter{
+		Base:  reqQuery.Base,
+		Quote: reqQuery.Quote,
+	})
+	switch err.(type) {
+	case nil:
+	case domain.ExchangeNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, getExchangePairsResponse{
+		Pairs: pairs,
+	})
+}
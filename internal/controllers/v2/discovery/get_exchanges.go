@@ -0,0 +1,51 @@
+package discoverycontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getExchangesRequestQuery struct {
+	Base  string `form:"base"`
+	Quote string `form:"quote"`
+}
+
+type getExchangesResponse struct {
+	Exchanges []domain.ExchangeSummary `json:"exchanges"`
+}
+
+// getExchanges godoc
+// @Summary Get Exchanges
+// @Description Lists exchanges with stored order books or history orders. Base and quote filter exchanges by the assets of their pairs, pair counts include only matching pairs.
+// @Tags Discovery
+// @Produce json
+// @Param base query string false "Base asset"
+// @Param quote query string false "Quote asset"
+// @Success 200 {object} getExchangesResponse "Exchanges"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges [get]
+func (c *DiscoveryController) getExchanges(ctx *gin.Context) {
+	var reqQuery getExchangesRequestQuery
+	err := ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	exchanges, err := c.marketDiscoveryService.GetExchanges(domain.AssetFilter{
+		Base:  reqQuery.Base,
+		Quote: reqQuery.Quote,
+	})
+	if err != nil {
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, getExchangesResponse{
+		Exchanges: exchanges,
+	})
+}
@@ -0,0 +1,9 @@
+// Package v2 groups the controllers of the v2 API, whose swagger documentation
+// is generated separately from v1 and served at /api/v2/swagger.
+package v2
+
+// @title           Market info storage
+// @version         2.0
+// @description     API to store and retreive market data
+
+// @BasePath  /api/v2
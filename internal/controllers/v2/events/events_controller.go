@@ -0,0 +1,29 @@
+package eventscontroller
+
+import (
+	"context"
+	"market-info-storage/internal/controllers"
+	"market-info-storage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EventsController struct {
+	eventLog EventLog
+}
+
+//go:generate mockery --name EventLog --filename event_log.go
+type EventLog interface {
+	LastEventID() uint64
+	WaitEvents(ctx context.Context, lastEventID uint64) ([]domain.Event, bool, error)
+}
+
+func NewEventsController(eventLog EventLog) controllers.Controller {
+	return &EventsController{
+		eventLog: eventLog,
+	}
+}
+
+func (c *EventsController) RegisterRoutes(engine *gin.Engine) {
+	engine.GET("/api/v2/events", c.streamEvents)
+}
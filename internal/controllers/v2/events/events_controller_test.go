@@ -0,0 +1,168 @@
+package eventscontroller
+
+import (
+	"context"
+	"market-info-storage/internal/controllers/v2/events/mocks"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamEventsResume(t *testing.T) {
+	reqCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := []domain.Event{
+		{ID: 6, Type: domain.EventTypeOrderBook, OrderBook: &domain.OrderBookUpdate{
+			ExchangeName: "binance",
+			Pair:         "SOL_USDT",
+			OrderBook:    &domain.OrderBook{Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}}},
+		}},
+		{ID: 7, Type: domain.EventTypeHistoryOrder, HistoryOrder: &domain.HistoryOrder{
+			ExchangeName: "binance",
+			Pair:         "SOL_USDT",
+		}},
+	}
+
+	eventLog := mocks.NewEventLog(t)
+	eventLog.On("WaitEvents", mock.Anything, uint64(5)).Return(events, true, nil).Once()
+	eventLog.On("WaitEvents", mock.Anything, uint64(7)).Run(func(mock.Arguments) { cancel() }).
+		Return(nil, true, context.Canceled).Once()
+	controller := NewEventsController(eventLog)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/events", nil).WithContext(reqCtx)
+	req.Header.Set("Last-Event-ID", "5")
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	require.Contains(t, w.Body.String(), "id: 6\nevent: order-book\ndata: {\"exchange\":\"binance\",\"pair\":\"SOL_USDT\"")
+	require.Contains(t, w.Body.String(), "id: 7\nevent: history-order\n")
+}
+
+func TestStreamEventsFilter(t *testing.T) {
+	reqCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := []domain.Event{
+		{ID: 1, Type: domain.EventTypeOrderBook, OrderBook: &domain.OrderBookUpdate{
+			ExchangeName: "binance",
+			Pair:         "SOL_USDT",
+			OrderBook:    &domain.OrderBook{},
+		}},
+		{ID: 2, Type: domain.EventTypeHistoryOrder, HistoryOrder: &domain.HistoryOrder{ExchangeName: "bybit"}},
+		{ID: 3, Type: domain.EventTypeHistoryOrder, HistoryOrder: &domain.HistoryOrder{ExchangeName: "binance"}},
+	}
+
+	eventLog := mocks.NewEventLog(t)
+	eventLog.On("LastEventID").Return(uint64(0))
+	eventLog.On("WaitEvents", mock.Anything, uint64(0)).Return(events, true, nil).Once()
+	eventLog.On("WaitEvents", mock.Anything, uint64(3)).Run(func(mock.Arguments) { cancel() }).
+		Return(nil, true, context.Canceled).Once()
+	controller := NewEventsController(eventLog)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/events?type=history-order&exchange=binance", nil).
+		WithContext(reqCtx)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "id: 1\n")
+	require.NotContains(t, w.Body.String(), "id: 2\n")
+	require.Contains(t, w.Body.String(), "id: 3\nevent: history-order\n")
+}
+
+func TestStreamEventsOrderBookDeleted(t *testing.T) {
+	reqCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := []domain.Event{
+		{ID: 4, Type: domain.EventTypeOrderBookDeleted, OrderBook: &domain.OrderBookUpdate{
+			ExchangeName: "binance",
+			Pair:         "LUNA_USDT",
+			OrderBook:    &domain.OrderBook{Version: 12},
+			Deleted:      true,
+		}},
+	}
+
+	eventLog := mocks.NewEventLog(t)
+	eventLog.On("WaitEvents", mock.Anything, uint64(3)).Return(events, true, nil).Once()
+	eventLog.On("WaitEvents", mock.Anything, uint64(4)).Run(func(mock.Arguments) { cancel() }).
+		Return(nil, true, context.Canceled).Once()
+	controller := NewEventsController(eventLog)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/events?type=order-book-deleted&exchange=binance", nil).
+		WithContext(reqCtx)
+	req.Header.Set("Last-Event-ID", "3")
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "id: 4\nevent: order-book-deleted\ndata: {\"exchange\":\"binance\",\"pair\":\"LUNA_USDT\"}\n\n")
+}
+
+func TestStreamEventsSnapshotRequired(t *testing.T) {
+	reqCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventLog := mocks.NewEventLog(t)
+	eventLog.On("WaitEvents", mock.Anything, uint64(1)).Return(nil, false, nil).Once()
+	eventLog.On("LastEventID").Return(uint64(500))
+	eventLog.On("WaitEvents", mock.Anything, uint64(500)).Run(func(mock.Arguments) { cancel() }).
+		Return(nil, true, context.Canceled).Once()
+	controller := NewEventsController(eventLog)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/events", nil).WithContext(reqCtx)
+	req.Header.Set("Last-Event-ID", "1")
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "id: 500\nevent: snapshot-required\ndata: {\"lastEventId\":500}\n\n")
+}
+
+func TestStreamEventsWrongRequest(t *testing.T) {
+	testCases := []struct {
+		name        string
+		query       string
+		lastEventID string
+	}{
+		{name: "invalid Last-Event-ID", lastEventID: "abc"},
+		{name: "unknown type", query: "type=trade"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eventLog := mocks.NewEventLog(t)
+			controller := NewEventsController(eventLog)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/events?"+tc.query, nil)
+			if tc.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package eventscontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+const (
+	eventTypeSnapshotRequired = "snapshot-required"
+	heartbeatPeriod           = 15 * time.Second
+)
+
+type streamEventsRequestHeader struct {
+	LastEventID string `header:"Last-Event-ID"`
+}
+
+type streamEventsRequestQuery struct {
+	Types         []domain.EventType `form:"type" binding:"dive,oneof=order-book order-book-deleted history-order"`
+	ExchangeNames []string           `form:"exchange"`
+}
+
+type orderBookEventData struct {
+	ExchangeName string              `json:"exchange"`
+	Pair         string              `json:"pair"`
+	Bids         []domain.DepthOrder `json:"bids"`
+	Asks         []domain.DepthOrder `json:"asks"`
+	Sequence     *int64              `json:"sequence,omitempty"`
+	UpdatedAt    time.Time           `json:"updatedAt"`
+	ExchangeTime *time.Time          `json:"exchangeTime,omitempty"`
+}
+
+type orderBookDeletedEventData struct {
+	ExchangeName string `json:"exchange"`
+	Pair         string `json:"pair"`
+}
+
+type snapshotRequiredEventData struct {
+	// LastEventID is the ID of the last event the feed is resumed after.
+	LastEventID uint64 `json:"lastEventId"`
+}
+
+// streamEvents godoc
+// @Summary Stream Events
+// @Description Streams order book updates and deletions and saved history orders as Server-Sent Events with increasing IDs. A client reconnecting with the Last-Event-ID header is first sent the events it missed. If they are no longer kept, a snapshot-required event is sent instead and the client has to reload the current state before applying further events. Event IDs are specific to the server instance.
+// @Tags Events
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "ID of the last received event"
+// @Param type query []string false "Event types, all types if none is chosen" Enums(order-book, order-book-deleted, history-order) collectionFormat(multi)
+// @Param exchange query []string false "Exchange names, all exchanges if none is chosen" collectionFormat(multi)
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Router /events [get]
+func (c *EventsController) streamEvents(ctx *gin.Context) {
+	var reqHeader streamEventsRequestHeader
+	err := ctx.BindHeader(&reqHeader)
+	if err != nil {
+		httputils.BadRequest(ctx, err)
+		return
+	}
+	var reqQuery streamEventsRequestQuery
+	err = ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	var lastEventID uint64
+	if reqHeader.LastEventID != "" {
+		lastEventID, err = strconv.ParseUint(reqHeader.LastEventID, 10, 64)
+		if err != nil {
+			httputils.BadRequest(ctx, errors.Wrap(err, "parse Last-Event-ID"))
+			return
+		}
+	} else {
+		lastEventID = c.eventLog.LastEventID()
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx.Request.Context(), heartbeatPeriod)
+		events, ok, err := c.eventLog.WaitEvents(waitCtx, lastEventID)
+		cancel()
+		switch {
+		case err != nil && ctx.Request.Context().Err() == nil:
+			fmt.Fprint(ctx.Writer, ": heartbeat\n\n")
+		case err != nil:
+			return
+		case !ok:
+			lastEventID = c.eventLog.LastEventID()
+			writeEvent(ctx, lastEventID, eventTypeSnapshotRequired, snapshotRequiredEventData{LastEventID: lastEventID})
+		default:
+			for _, event := range events {
+				if matchEvent(event, reqQuery) {
+					writeEvent(ctx, event.ID, string(event.Type), newEventData(event))
+				}
+			}
+			lastEventID = events[len(events)-1].ID
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+func matchEvent(event domain.Event, reqQuery streamEventsRequestQuery) bool {
+	if len(reqQuery.Types) > 0 && !slices.Contains(reqQuery.Types, event.Type) {
+		return false
+	}
+	if len(reqQuery.ExchangeNames) == 0 {
+		return true
+	}
+	switch event.Type {
+	case domain.EventTypeOrderBook, domain.EventTypeOrderBookDeleted:
+		return slices.Contains(reqQuery.ExchangeNames, event.OrderBook.ExchangeName)
+	case domain.EventTypeHistoryOrder:
+		return slices.Contains(reqQuery.ExchangeNames, event.HistoryOrder.ExchangeName)
+	default:
+		return false
+	}
+}
+
+func newEventData(event domain.Event) any {
+	switch event.Type {
+	case domain.EventTypeHistoryOrder:
+		return event.HistoryOrder
+	case domain.EventTypeOrderBookDeleted:
+		return orderBookDeletedEventData{ExchangeName: event.OrderBook.ExchangeName, Pair: event.OrderBook.Pair}
+	}
+	data := orderBookEventData{
+		ExchangeName: event.OrderBook.ExchangeName,
+		Pair:         event.OrderBook.Pair,
+		Bids:         event.OrderBook.OrderBook.Bids,
+		Asks:         event.OrderBook.OrderBook.Asks,
+		Sequence:     event.OrderBook.OrderBook.Sequence,
+		UpdatedAt:    event.OrderBook.OrderBook.UpdatedAt,
+		ExchangeTime: event.OrderBook.OrderBook.ExchangeTime,
+	}
+	if data.Bids == nil {
+		data.Bids = []domain.DepthOrder{}
+	}
+	if data.Asks == nil {
+		data.Asks = []domain.DepthOrder{}
+	}
+	return data
+}
+
+func writeEvent(ctx *gin.Context, id uint64, eventType string, data any) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(ctx.Writer, "id: %d\nevent: %s\ndata: %s\n\n", id, eventType, dataJSON)
+}
@@ -0,0 +1,45 @@
+package exchangecontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteExchangeRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+}
+
+// deleteExchange godoc
+// @Summary Delete Exchange
+// @Description Removes an exchange from the registry, so that its data is no longer accepted. Stored data of the exchange is kept.
+// @Tags Admin
+// @Param exchange path string true "Exchange name"
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "Exchange not found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /admin/exchanges/{exchange} [delete]
+func (c *ExchangeController) deleteExchange(ctx *gin.Context) {
+	var req deleteExchangeRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+
+	err = c.exchangeService.DeleteExchange(req.ExchangeName)
+	switch err.(type) {
+	case nil:
+	case domain.ExchangeNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,34 @@
+package exchangecontroller
+
+import (
+	"market-info-storage/internal/controllers"
+	"market-info-storage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeController struct {
+	exchangeService ExchangeService
+}
+
+//go:generate mockery --name ExchangeService --filename exchange_service.go
+type ExchangeService interface {
+	SaveExchange(exchange *domain.Exchange) error
+	GetExchange(exchangeName string) (*domain.Exchange, error)
+	GetExchanges() ([]domain.Exchange, error)
+	DeleteExchange(exchangeName string) error
+}
+
+func NewExchangeController(exchangeService ExchangeService) controllers.Controller {
+	return &ExchangeController{
+		exchangeService: exchangeService,
+	}
+}
+
+func (c *ExchangeController) RegisterRoutes(engine *gin.Engine) {
+	exchangesGroup := engine.Group("/api/v2/admin/exchanges")
+	exchangesGroup.GET("", c.getExchanges)
+	exchangesGroup.PUT("/:exchange", c.saveExchange)
+	exchangesGroup.GET("/:exchange", c.getExchange)
+	exchangesGroup.DELETE("/:exchange", c.deleteExchange)
+}
@@ -0,0 +1,204 @@
+package exchangecontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"market-info-storage/internal/controllers/v2/exchange/mocks"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveExchange(t *testing.T) {
+	takerFee := 0.001
+	makerFee := 0.0008
+
+	service := mocks.NewExchangeService(t)
+	service.On("SaveExchange", &domain.Exchange{
+		Name:                   "binance",
+		DisplayName:            "Binance",
+		Enabled:                false,
+		TakerFee:               &takerFee,
+		MakerFee:               &makerFee,
+		ValidationMode:         domain.ValidationModeLenient,
+		SnapshotRetentionHours: 72,
+	}).Return(nil)
+	controller := NewExchangeController(service)
+
+	body := `{"displayName": "Binance", "enabled": false, "takerFee": 0.001, "makerFee": 0.0008,
+		"validationMode": "lenient", "snapshotRetentionHours": 72}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/admin/exchanges/binance", strings.NewReader(body))
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestSaveExchangeDefaults(t *testing.T) {
+	service := mocks.NewExchangeService(t)
+	service.On("SaveExchange", &domain.Exchange{
+		Name:    "bybit",
+		Enabled: true,
+	}).Return(nil)
+	controller := NewExchangeController(service)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/admin/exchanges/bybit", strings.NewReader(`{}`))
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestSaveExchangeWrongFormat(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{name: "NegativeTakerFee", body: `{"takerFee": -0.001}`},
+		{name: "WholeMakerFee", body: `{"makerFee": 1}`},
+		{name: "UnknownValidationMode", body: `{"validationMode": "loose"}`},
+		{name: "NegativeRetention", body: `{"snapshotRetentionHours": -1}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := mocks.NewExchangeService(t)
+			controller := NewExchangeController(service)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v2/admin/exchanges/binance", strings.NewReader(tc.body))
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+		})
+	}
+}
+
+func TestSaveInvalidExchange(t *testing.T) {
+	service := mocks.NewExchangeService(t)
+	service.On("SaveExchange", &domain.Exchange{
+		Name:    " ",
+		Enabled: true,
+	}).Return(domain.ExchangeInvalid{Message: "name is empty"})
+	controller := NewExchangeController(service)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/admin/exchanges/%20", strings.NewReader(`{}`))
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestGetExchange(t *testing.T) {
+	takerFee := 0.001
+	exchange := &domain.Exchange{
+		Name:                   "binance",
+		DisplayName:            "Binance",
+		Enabled:                true,
+		TakerFee:               &takerFee,
+		SnapshotRetentionHours: 24,
+	}
+
+	service := mocks.NewExchangeService(t)
+	service.On("GetExchange", "binance").Return(exchange, nil)
+	controller := NewExchangeController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/admin/exchanges/binance", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody domain.Exchange
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, *exchange, respBody)
+}
+
+func TestGetNonExistentExchange(t *testing.T) {
+	service := mocks.NewExchangeService(t)
+	service.On("GetExchange", "binance").Return(nil, domain.ExchangeNotFound{})
+	controller := NewExchangeController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/admin/exchanges/binance", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetExchanges(t *testing.T) {
+	exchanges := []domain.Exchange{
+		{Name: "binance", DisplayName: "Binance", Enabled: true},
+		{Name: "bybit", DisplayName: "Bybit", ValidationMode: domain.ValidationModeStrict},
+	}
+
+	service := mocks.NewExchangeService(t)
+	service.On("GetExchanges").Return(exchanges, nil)
+	controller := NewExchangeController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/admin/exchanges", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getExchangesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, exchanges, respBody.Exchanges)
+}
+
+func TestDeleteExchange(t *testing.T) {
+	service := mocks.NewExchangeService(t)
+	service.On("DeleteExchange", "binance").Return(nil)
+	controller := NewExchangeController(service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/admin/exchanges/binance", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestDeleteNonExistentExchange(t *testing.T) {
+	service := mocks.NewExchangeService(t)
+	service.On("DeleteExchange", "binance").Return(domain.ExchangeNotFound{})
+	controller := NewExchangeController(service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/admin/exchanges/binance", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,46 @@
+package exchangecontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getExchangeRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+}
+
+// getExchange godoc
+// @Summary Get Exchange
+// @Description Retrieves registry settings of an exchange.
+// @Tags Admin
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Success 200 {object} domain.Exchange "Exchange"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "Exchange not found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /admin/exchanges/{exchange} [get]
+func (c *ExchangeController) getExchange(ctx *gin.Context) {
+	var req getExchangeRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+
+	exchange, err := c.exchangeService.GetExchange(req.ExchangeName)
+	switch err.(type) {
+	case nil:
+	case domain.ExchangeNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exchange)
+}
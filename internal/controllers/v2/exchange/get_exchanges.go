@@ -0,0 +1,33 @@
+package exchangecontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getExchangesResponse struct {
+	Exchanges []domain.Exchange `json:"exchanges"`
+}
+
+// getExchanges godoc
+// @Summary Get Registered Exchanges
+// @Description Lists registered exchanges with their settings, including disabled ones, sorted by name.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} getExchangesResponse "Exchanges"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /admin/exchanges [get]
+func (c *ExchangeController) getExchanges(ctx *gin.Context) {
+	exchanges, err := c.exchangeService.GetExchanges()
+	if err != nil {
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, getExchangesResponse{
+		Exchanges: exchanges,
+	})
+}
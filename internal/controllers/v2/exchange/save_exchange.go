@@ -0,0 +1,75 @@
+package exchangecontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type saveExchangeRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+}
+
+type saveExchangeRequestBody struct {
+	// DisplayName defaults to the exchange name.
+	DisplayName string `json:"displayName"`
+	// Enabled defaults to true.
+	Enabled        *bool    `json:"enabled"`
+	TakerFee       *float64 `json:"takerFee" binding:"omitempty,min=0,lt=1"`
+	MakerFee       *float64 `json:"makerFee" binding:"omitempty,min=0,lt=1"`
+	ValidationMode string   `json:"validationMode" binding:"omitempty,oneof=strict lenient"`
+	// SnapshotRetentionHours of 0 keeps snapshots forever, a change applies to snapshots saved after it.
+	SnapshotRetentionHours int `json:"snapshotRetentionHours" binding:"min=0"`
+}
+
+// saveExchange godoc
+// @Summary Save Exchange
+// @Description Registers an exchange or replaces its settings. Order books, deltas and history orders are accepted only for registered and enabled exchanges. Fees are rates such as 0.001 for 0.1%, the taker fee overrides the configured arbitrage taker fee and the validation mode the configured one. Snapshots older than the snapshot retention are deleted periodically.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Param settings body saveExchangeRequestBody true "Exchange settings"
+// @Success 200 {object} domain.Exchange "Saved exchange"
+// @Failure 400 {object} httputils.HTTPError "Invalid request body"
+// @Failure 422 {object} httputils.HTTPError "Invalid exchange settings"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /admin/exchanges/{exchange} [put]
+func (c *ExchangeController) saveExchange(ctx *gin.Context) {
+	var req saveExchangeRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqBody saveExchangeRequestBody
+	err = ctx.BindJSON(&reqBody)
+	if err != nil {
+		httputils.BindJSONBodyError(ctx, err)
+		return
+	}
+
+	exchange := &domain.Exchange{
+		Name:                   req.ExchangeName,
+		DisplayName:            reqBody.DisplayName,
+		Enabled:                reqBody.Enabled == nil || *reqBody.Enabled,
+		TakerFee:               reqBody.TakerFee,
+		MakerFee:               reqBody.MakerFee,
+		ValidationMode:         domain.ValidationMode(reqBody.ValidationMode),
+		SnapshotRetentionHours: reqBody.SnapshotRetentionHours,
+	}
+	err = c.exchangeService.SaveExchange(exchange)
+	switch err.(type) {
+	case nil:
+	case domain.ExchangeInvalid:
+		httputils.UnprocessableEntityError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, exchange)
+}
@@ -0,0 +1,47 @@
+package instrumentcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteInstrumentRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+// deleteInstrument godoc
+// @Summary Delete Instrument
+// @Description Deletes the instrument of a pair on an exchange, its order books and history orders are no longer validated against it.
+// @Tags Instrument
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "Instrument not found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/instruments/{pair} [delete]
+func (c *InstrumentController) deleteInstrument(ctx *gin.Context) {
+	var req deleteInstrumentRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+
+	err = c.instrumentService.DeleteInstrument(req.ExchangeName, req.Pair)
+	switch err.(type) {
+	case nil:
+	case domain.InstrumentNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
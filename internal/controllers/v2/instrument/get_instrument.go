@@ -0,0 +1,48 @@
+package instrumentcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getInstrumentRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+// getInstrument godoc
+// @Summary Get Instrument
+// @Description Retrieves the instrument of a pair on an exchange.
+// @Tags Instrument
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Success 200 {object} domain.Instrument "Instrument"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "Instrument not found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/instruments/{pair} [get]
+func (c *InstrumentController) getInstrument(ctx *gin.Context) {
+	var req getInstrumentRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+
+	instrument, err := c.instrumentService.GetInstrument(req.ExchangeName, req.Pair)
+	switch err.(type) {
+	case nil:
+	case domain.InstrumentNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, instrument)
+}
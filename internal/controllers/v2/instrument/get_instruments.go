@@ -0,0 +1,46 @@
+package instrumentcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getInstrumentsRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+}
+
+type getInstrumentsResponse struct {
+	Instruments []domain.Instrument `json:"instruments"`
+}
+
+// getInstruments godoc
+// @Summary Get Instruments
+// @Description Lists instruments of an exchange sorted by pair.
+// @Tags Instrument
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Success 200 {object} getInstrumentsResponse "Instruments"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/instruments [get]
+func (c *InstrumentController) getInstruments(ctx *gin.Context) {
+	var req getInstrumentsRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+
+	instruments, err := c.instrumentService.GetInstruments(req.ExchangeName)
+	if err != nil {
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, getInstrumentsResponse{
+		Instruments: instruments,
+	})
+}
@@ -0,0 +1,34 @@
+package instrumentcontroller
+
+import (
+	"market-info-storage/internal/controllers"
+	"market-info-storage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InstrumentController struct {
+	instrumentService InstrumentService
+}
+
+//go:generate mockery --name InstrumentService --filename instrument_service.go
+type InstrumentService interface {
+	SaveInstrument(instrument *domain.Instrument) error
+	GetInstrument(exchangeName, pair string) (*domain.Instrument, error)
+	GetInstruments(exchangeName string) ([]domain.Instrument, error)
+	DeleteInstrument(exchangeName, pair string) error
+}
+
+func NewInstrumentController(instrumentService InstrumentService) controllers.Controller {
+	return &InstrumentController{
+		instrumentService: instrumentService,
+	}
+}
+
+func (c *InstrumentController) RegisterRoutes(engine *gin.Engine) {
+	instrumentsGroup := engine.Group("/api/v2/exchanges/:exchange/instruments")
+	instrumentsGroup.GET("", c.getInstruments)
+	instrumentsGroup.PUT("/:pair", c.saveInstrument)
+	instrumentsGroup.GET("/:pair", c.getInstrument)
+	instrumentsGroup.DELETE("/:pair", c.deleteInstrument)
+}
@@ -0,0 +1,187 @@
+package instrumentcontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"market-info-storage/internal/controllers/v2/instrument/mocks"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveInstrument(t *testing.T) {
+	service := mocks.NewInstrumentService(t)
+	service.On("SaveInstrument", &domain.Instrument{
+		ExchangeName: "binance",
+		Pair:         "BTCUSDT",
+		TickSize:     decimal.RequireFromString("0.01"),
+		QtyStep:      decimal.RequireFromString("0.00001"),
+		MinNotional:  decimal.RequireFromString("5"),
+	}).Return(nil)
+	controller := NewInstrumentController(service)
+
+	body := `{"tickSize": "0.01", "qtyStep": "0.00001", "minNotional": "5"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/exchanges/binance/instruments/BTCUSDT", strings.NewReader(body))
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Contains(t, w.Body.String(), `"tickSize":"0.01"`)
+}
+
+func TestSaveInstrumentWrongFormat(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{name: "MissingTickSize", body: `{"qtyStep": "0.1"}`},
+		{name: "MissingQtyStep", body: `{"tickSize": "0.1"}`},
+		{name: "MalformedTickSize", body: `{"tickSize": "tick", "qtyStep": "0.1"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := mocks.NewInstrumentService(t)
+			controller := NewInstrumentController(service)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v2/exchanges/binance/instruments/BTC_USDT", strings.NewReader(tc.body))
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+		})
+	}
+}
+
+func TestSaveInvalidInstrument(t *testing.T) {
+	service := mocks.NewInstrumentService(t)
+	service.On("SaveInstrument", &domain.Instrument{
+		ExchangeName: "binance",
+		Pair:         "BTC_USDT",
+		TickSize:     decimal.RequireFromString("0"),
+		QtyStep:      decimal.RequireFromString("0.1"),
+	}).Return(domain.InstrumentInvalid{Message: "tick size is not positive"})
+	controller := NewInstrumentController(service)
+
+	body := `{"tickSize": "0", "qtyStep": "0.1"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/exchanges/binance/instruments/BTC_USDT", strings.NewReader(body))
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestGetInstrument(t *testing.T) {
+	instrument := &domain.Instrument{
+		ExchangeName: "binance",
+		Pair:         "BTC_USDT",
+		Base:         "BTC",
+		Quote:        "USDT",
+		TickSize:     decimal.RequireFromString("0.01"),
+		QtyStep:      decimal.RequireFromString("0.00001"),
+		MinNotional:  decimal.RequireFromString("5"),
+	}
+
+	service := mocks.NewInstrumentService(t)
+	service.On("GetInstrument", "binance", "BTC_USDT").Return(instrument, nil)
+	controller := NewInstrumentController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/instruments/BTC_USDT", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody domain.Instrument
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, *instrument, respBody)
+}
+
+func TestGetNonExistentInstrument(t *testing.T) {
+	service := mocks.NewInstrumentService(t)
+	service.On("GetInstrument", "binance", "BTC_USDT").Return(nil, domain.InstrumentNotFound{})
+	controller := NewInstrumentController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/instruments/BTC_USDT", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetInstruments(t *testing.T) {
+	instruments := []domain.Instrument{
+		{ExchangeName: "bybit", Pair: "BTC_USDT", Base: "BTC", Quote: "USDT",
+			TickSize: decimal.RequireFromString("0.1"), QtyStep: decimal.RequireFromString("0.001"), MinNotional: decimal.RequireFromString("5")},
+		{ExchangeName: "bybit", Pair: "ETH_USDT", Base: "ETH", Quote: "USDT",
+			TickSize: decimal.RequireFromString("0.01"), QtyStep: decimal.RequireFromString("0.01"), MinNotional: decimal.RequireFromString("1")},
+	}
+
+	service := mocks.NewInstrumentService(t)
+	service.On("GetInstruments", "bybit").Return(instruments, nil)
+	controller := NewInstrumentController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/bybit/instruments", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getInstrumentsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, instruments, respBody.Instruments)
+}
+
+func TestDeleteInstrument(t *testing.T) {
+	service := mocks.NewInstrumentService(t)
+	service.On("DeleteInstrument", "binance", "BTC_USDT").Return(nil)
+	controller := NewInstrumentController(service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/exchanges/binance/instruments/BTC_USDT", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestDeleteNonExistentInstrument(t *testing.T) {
+	service := mocks.NewInstrumentService(t)
+	service.On("DeleteInstrument", "binance", "BTC_USDT").Return(domain.InstrumentNotFound{})
+	controller := NewInstrumentController(service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/exchanges/binance/instruments/BTC_USDT", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,79 @@
+package instrumentcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+)
+
+type saveInstrumentRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+type saveInstrumentRequestBody struct {
+	// Base and Quote are taken from the pair if omitted.
+	Base        string           `json:"base"`
+	Quote       string           `json:"quote"`
+	TickSize    *decimal.Decimal `json:"tickSize" binding:"required" swaggertype:"string"`
+	QtyStep     *decimal.Decimal `json:"qtyStep" binding:"required" swaggertype:"string"`
+	MinNotional decimal.Decimal  `json:"minNotional" swaggertype:"string"`
+}
+
+// saveInstrument godoc
+// @Summary Save Instrument
+// @Description Creates or replaces the instrument of a pair on an exchange. Base and quote assets are taken from the pair if omitted. Tick size and quantity step must be positive, a minimum notional of 0 sets no minimum. The exchange must be registered.
+// @Tags Instrument
+// @Accept json
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Param instrument body saveInstrumentRequestBody true "Instrument"
+// @Success 200 {object} domain.Instrument "Saved instrument"
+// @Failure 400 {object} httputils.HTTPError "Invalid request body"
+// @Failure 404 {object} httputils.HTTPError "Exchange is not registered"
+// @Failure 422 {object} httputils.HTTPError "Invalid instrument"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/instruments/{pair} [put]
+func (c *InstrumentController) saveInstrument(ctx *gin.Context) {
+	var req saveInstrumentRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqBody saveInstrumentRequestBody
+	err = ctx.BindJSON(&reqBody)
+	if err != nil {
+		httputils.BindJSONBodyError(ctx, err)
+		return
+	}
+
+	instrument := &domain.Instrument{
+		ExchangeName: req.ExchangeName,
+		Pair:         req.Pair,
+		Base:         reqBody.Base,
+		Quote:        reqBody.Quote,
+		TickSize:     *reqBody.TickSize,
+		QtyStep:      *reqBody.QtyStep,
+		MinNotional:  reqBody.MinNotional,
+	}
+	err = c.instrumentService.SaveInstrument(instrument)
+	switch err.(type) {
+	case nil:
+	case domain.InstrumentInvalid:
+		httputils.UnprocessableEntityError(ctx, err)
+		return
+	case domain.ExchangeNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, instrument)
+}
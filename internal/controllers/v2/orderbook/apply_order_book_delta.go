@@ -0,0 +1,89 @@
+package orderbookcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type applyOrderBookDeltaRequestURI struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+type applyOrderBookDeltaRequestBody struct {
+	Sequence *int64              `json:"sequence" binding:"required"`
+	Bids     []domain.DepthOrder `json:"bids"`
+	Asks     []domain.DepthOrder `json:"asks"`
+	// ExchangeTime is the time the exchange reported for the delta.
+	ExchangeTime *time.Time `json:"exchangeTime"`
+	// Checksum is the CRC32 checksum of the top levels of the book with the delta applied.
+	Checksum *int64 `json:"checksum"`
+	// ChecksumAlgorithm is the algorithm of Checksum: okx or kraken, the exchange name is used if empty.
+	ChecksumAlgorithm string `json:"checksumAlgorithm"`
+}
+
+// applyOrderBookDelta godoc
+// @Summary Apply Order Book Delta
+// @Description Upserts levels of the stored order book of a registered and enabled exchange, a level with zero baseQty removes the level with the same price. The delta sequence number must directly follow the sequence number of the last applied snapshot or delta, otherwise the delta is rejected and all further deltas are rejected until a new snapshot is saved. An optional checksum is verified against the book with the delta applied, a mismatch leaves the stored book unchanged.
+// @Tags OrderBook
+// @Accept json
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Param delta body applyOrderBookDeltaRequestBody true "Order Book delta"
+// @Success 200
+// @Failure 400 {object} httputils.HTTPError "Invalid request body"
+// @Failure 403 {object} httputils.HTTPError "Exchange is disabled"
+// @Failure 404 {object} httputils.HTTPError "Order Book not found or exchange is not registered"
+// @Failure 409 {object} httputils.HTTPError "Resync required"
+// @Failure 422 {object} httputils.HTTPValidationError "Delta or resulting Order Book violates invariants or does not match the checksum"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/pairs/{pair}/order-book [patch]
+func (c *OrderBookController) applyOrderBookDelta(ctx *gin.Context) {
+	var reqURI applyOrderBookDeltaRequestURI
+	err := ctx.BindUri(&reqURI)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqBody applyOrderBookDeltaRequestBody
+	err = ctx.BindJSON(&reqBody)
+	if err != nil {
+		httputils.BindJSONBodyError(ctx, err)
+		return
+	}
+
+	err = c.orderBookService.ApplyOrderBookDelta(reqURI.ExchangeName, reqURI.Pair, &domain.OrderBookDelta{
+		Sequence:     *reqBody.Sequence,
+		Bids:         reqBody.Bids,
+		Asks:         reqBody.Asks,
+		ExchangeTime: reqBody.ExchangeTime,
+		Checksum:     orderBookChecksum(reqBody.Checksum, reqBody.ChecksumAlgorithm),
+	})
+	switch err := err.(type) {
+	case nil:
+	case domain.ExchangeNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	case domain.ExchangeDisabled:
+		httputils.ForbiddenError(ctx, err)
+		return
+	case domain.OrderBookNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	case domain.OrderBookResyncRequired:
+		httputils.ConflictError(ctx, err)
+		return
+	case domain.OrderBookInvalid:
+		httputils.OrderBookInvalidError(ctx, err)
+		return
+	case domain.OrderBookChecksumMismatch, domain.ChecksumAlgorithmUnknown:
+		httputils.UnprocessableEntityError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+}
@@ -0,0 +1,11 @@
+package orderbookcontroller
+
+import "market-info-storage/internal/domain"
+
+// orderBookChecksum returns nil if the request has no checksum, the algorithm alone is ignored.
+func orderBookChecksum(checksum *int64, algorithm string) *domain.OrderBookChecksum {
+	if checksum == nil {
+		return nil
+	}
+	return &domain.OrderBookChecksum{Algorithm: algorithm, Value: *checksum}
+}
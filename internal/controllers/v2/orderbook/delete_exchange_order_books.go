@@ -0,0 +1,45 @@
+package orderbookcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteExchangeOrderBooksRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+}
+
+// deleteExchangeOrderBooks godoc
+// @Summary Delete Exchange Order Books
+// @Description Deletes current order books of all pairs of the exchange. The snapshot history is kept.
+// @Tags OrderBook
+// @Param exchange path string true "Exchange name"
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "No Order Books found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/order-books [delete]
+func (c *OrderBookController) deleteExchangeOrderBooks(ctx *gin.Context) {
+	var req deleteExchangeOrderBooksRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+
+	_, err = c.orderBookService.DeleteExchangeOrderBooks(req.ExchangeName)
+	switch err.(type) {
+	case nil:
+	case domain.OrderBookNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package orderbookcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type deleteOrderBookRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+// deleteOrderBook godoc
+// @Summary Delete Order Book
+// @Description Deletes the current order book of a delisted pair so it is no longer served. The snapshot history is kept.
+// @Tags OrderBook
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Success 204
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "Order Book not found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/pairs/{pair}/order-book [delete]
+func (c *OrderBookController) deleteOrderBook(ctx *gin.Context) {
+	var req deleteOrderBookRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+
+	err = c.orderBookService.DeleteOrderBook(req.ExchangeName, req.Pair)
+	switch err.(type) {
+	case nil:
+	case domain.OrderBookNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+package orderbookcontroller
+
+import (
+	"errors"
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type estimateMarketImpactRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+type estimateMarketImpactRequestQuery struct {
+	Side     domain.OrderSide `form:"side" binding:"required,oneof=buy sell"`
+	BaseQty  float64          `form:"base-qty" binding:"min=0"`
+	QuoteQty float64          `form:"quote-qty" binding:"min=0"`
+}
+
+type estimateMarketImpactResponse struct {
+	Impact *domain.MarketImpact `json:"impact"`
+}
+
+// estimateMarketImpact godoc
+// @Summary Estimate Market Impact
+// @Description Walks the stored order book with a market order sized either in base quantity or in quote notional and returns the VWAP fill price, the worst price reached, the number of levels consumed, slippage versus the mid price in basis points and the unfilled remainder.
+// @Tags OrderBook
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Param side query string true "Order side" Enums(buy, sell)
+// @Param base-qty query number false "Order size in base asset"
+// @Param quote-qty query number false "Order size in quote asset"
+// @Success 200 {object} estimateMarketImpactResponse "Market impact"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "Order Book not found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/pairs/{pair}/order-book/impact [get]
+func (c *OrderBookController) estimateMarketImpact(ctx *gin.Context) {
+	var req estimateMarketImpactRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqQuery estimateMarketImpactRequestQuery
+	err = ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+	err = validateEstimateMarketImpactRequestQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	impact, err := c.orderBookService.EstimateMarketImpact(
+		req.ExchangeName, req.Pair, reqQuery.Side, reqQuery.BaseQty, reqQuery.QuoteQty)
+	switch err.(type) {
+	case nil:
+	case domain.OrderBookNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, estimateMarketImpactResponse{
+		Impact: impact,
+	})
+}
+
+func validateEstimateMarketImpactRequestQuery(reqQuery *estimateMarketImpactRequestQuery) error {
+	if (reqQuery.BaseQty > 0) == (reqQuery.QuoteQty > 0) {
+		return errors.New("exactly one of base-qty and quote-qty should be positive")
+	}
+	return nil
+}
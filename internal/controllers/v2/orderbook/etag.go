@@ -0,0 +1,58 @@
+package orderbookcontroller
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// orderBookETag returns the strong ETag of an order book version.
+func orderBookETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// orderBookRepresentationETag returns the strong ETag of an order book version shaped by
+// representation, the encoded read parameters. Shaped representations of the same version
+// differ in levels, so their parameters are part of the tag, "12-depth=10&side=bid".
+// The tag of the whole book is the version tag accepted by If-Match.
+func orderBookRepresentationETag(version int64, representation url.Values) string {
+	if len(representation) == 0 {
+		return orderBookETag(version)
+	}
+	return `"` + strconv.FormatInt(version, 10) + "-" + representation.Encode() + `"`
+}
+
+// matchETag reports whether an If-None-Match header lists etag or "*", tags are compared weakly.
+func matchETag(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// parseETagVersions parses versions from an If-Match header, all is set for "*". Weak tags
+// are skipped as If-Match requires strong comparison, so are tags that are not order book versions.
+func parseETagVersions(header string) (versions []int64, all bool) {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			all = true
+			continue
+		}
+		if strings.HasPrefix(tag, "W/") {
+			continue
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	return versions, all
+}
@@ -0,0 +1,81 @@
+package orderbookcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+)
+
+type getConsolidatedOrderBookRequest struct {
+	Pair string `uri:"pair" binding:"required"`
+}
+
+type getConsolidatedOrderBookRequestQuery struct {
+	ExchangeNames []string        `form:"exchange"`
+	Depth         int             `form:"depth" binding:"min=0"`
+	Side          domain.Side     `form:"side" binding:"omitempty,oneof=bid ask"`
+	Group         decimal.Decimal `form:"group"`
+}
+
+type getConsolidatedOrderBookResponse struct {
+	Bids []domain.ConsolidatedDepthOrder `json:"bids"`
+	Asks []domain.ConsolidatedDepthOrder `json:"asks"`
+}
+
+// getConsolidatedOrderBook godoc
+// @Summary Get Consolidated Order Book
+// @Description Merges stored order books of the pair from the chosen exchanges, or from all exchanges if none is chosen. Every level carries quantities contributed by each exchange. Side and group are applied to every book before merging, depth is applied to the merged levels.
+// @Tags OrderBook
+// @Produce json
+// @Param pair path string true "Currency Pair"
+// @Param exchange query []string false "Exchange names" collectionFormat(multi)
+// @Param depth query int false "Maximum number of levels per side, 0 for all levels"
+// @Param side query string false "Side to return" Enums(bid, ask)
+// @Param group query number false "Price step to group levels by"
+// @Success 200 {object} getConsolidatedOrderBookResponse "Consolidated Order Book"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "No Order Books found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /pairs/{pair}/order-book [get]
+func (c *OrderBookController) getConsolidatedOrderBook(ctx *gin.Context) {
+	var req getConsolidatedOrderBookRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqQuery getConsolidatedOrderBookRequestQuery
+	err = ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+	err = validateGroup(reqQuery.Group)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	orderBook, err := c.orderBookService.GetConsolidatedOrderBook(req.Pair, reqQuery.ExchangeNames, domain.OrderBookReadOptions{
+		Depth: reqQuery.Depth,
+		Side:  reqQuery.Side,
+		Group: reqQuery.Group,
+	})
+	switch err.(type) {
+	case nil:
+	case domain.OrderBookNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, getConsolidatedOrderBookResponse{
+		Bids: orderBook.Bids,
+		Asks: orderBook.Asks,
+	})
+}
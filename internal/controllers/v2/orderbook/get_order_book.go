@@ -0,0 +1,211 @@
+package orderbookcontroller
+
+import (
+	"errors"
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+)
+
+type getOrderBookRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+type getOrderBookRequestQuery struct {
+	At    *time.Time      `form:"at"`
+	Depth int             `form:"depth" binding:"min=0"`
+	Side  domain.Side     `form:"side" binding:"omitempty,oneof=bid ask"`
+	Group decimal.Decimal `form:"group"`
+	// MaxAge is ignored for point-in-time requests.
+	MaxAge time.Duration `form:"max_age" binding:"min=0"`
+	// Precision set to instrument formats levels with the decimal places of the pair's instrument.
+	Precision string `form:"precision" binding:"omitempty,oneof=instrument"`
+}
+
+type getOrderBookResponse struct {
+	Bids     []domain.DepthOrder `json:"bids"`
+	Asks     []domain.DepthOrder `json:"asks"`
+	Sequence *int64              `json:"sequence,omitempty"`
+	// UpdatedAt is the time the book was last saved or updated by a delta.
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	ExchangeTime *time.Time `json:"exchangeTime,omitempty"`
+	// Stale is set when the book stopped updating.
+	Stale bool `json:"stale"`
+	// Time is the time the snapshot was saved, set only for point-in-time requests.
+	Time *time.Time `json:"time,omitempty"`
+}
+
+// getFormattedOrderBookResponse replaces the levels of getOrderBookResponse with formatted ones.
+type getFormattedOrderBookResponse struct {
+	getOrderBookResponse
+	Bids []domain.FormattedDepthOrder `json:"bids"`
+	Asks []domain.FormattedDepthOrder `json:"asks"`
+}
+
+// getOrderBook godoc
+// @Summary Get Order Book
+// @Description Retrieves the order book for a specific exchange and pair with bids and asks returned separately. With the at parameter the latest snapshot saved at or before that moment is returned. Depth limits the number of levels per side, side restricts the result to bids or asks, the other side is returned empty. The Last-Modified header carries the time the book was last updated and the ETag header its version, followed by depth, side, group and precision when they are set as they change the levels, a request with If-None-Match matching the current ETag is answered with 304 Not Modified. With max_age a book updated longer ago is rejected as stale instead of being returned. With precision=instrument prices and quantities are formatted with the decimal places of the tick size and quantity step of the pair's instrument, keeping trailing zeros, values with more decimal places are returned unrounded.
+// @Tags OrderBook
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Param at query string false "Point in time, RFC 3339"
+// @Param depth query int false "Maximum number of levels per side, 0 for all levels"
+// @Param side query string false "Side to return" Enums(bid, ask)
+// @Param group query number false "Price step to group levels by"
+// @Param max_age query string false "Maximum age of the book, Go duration such as 5s"
+// @Param precision query string false "Format levels with the precision of the pair's instrument" Enums(instrument)
+// @Param If-None-Match header string false "ETags of the book known to the client"
+// @Success 200 {object} getOrderBookResponse "Order Book data"
+// @Header 200 {string} Last-Modified "Time the book was last updated"
+// @Header 200 {string} ETag "Version of the book and the parameters shaping its levels"
+// @Success 304 "Order Book has not changed"
+// @Failure 400 {object} httputils.HTTPError "Invalid request body"
+// @Failure 404 {object} httputils.HTTPError "Order Book or instrument not found"
+// @Failure 409 {object} httputils.HTTPError "Order Book is older than max_age"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/pairs/{pair}/order-book [get]
+func (c *OrderBookController) getOrderBook(ctx *gin.Context) {
+	var req getOrderBookRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqQuery getOrderBookRequestQuery
+	err = ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+	err = validateGroup(reqQuery.Group)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	opts := domain.OrderBookReadOptions{
+		Depth: reqQuery.Depth,
+		Side:  reqQuery.Side,
+		Group: reqQuery.Group,
+	}
+	if reqQuery.At == nil {
+		opts.MaxAge = reqQuery.MaxAge
+	}
+	var instrument *domain.Instrument
+	if reqQuery.Precision == "instrument" {
+		instrument, err = c.orderBookService.GetInstrument(req.ExchangeName, req.Pair)
+		switch err.(type) {
+		case nil:
+		case domain.InstrumentNotFound:
+			httputils.NotFoundError(ctx, err)
+			return
+		default:
+			httputils.InternalError(ctx)
+			return
+		}
+	}
+
+	var resp getOrderBookResponse
+	var version int64
+	if reqQuery.At != nil {
+		var snapshot *domain.OrderBookSnapshot
+		snapshot, err = c.orderBookService.GetOrderBookAt(req.ExchangeName, req.Pair, *reqQuery.At, opts)
+		if err == nil {
+			resp = newGetOrderBookResponse(snapshot.OrderBook)
+			resp.Time = &snapshot.Time
+		}
+	} else {
+		var orderBook *domain.OrderBook
+		orderBook, err = c.orderBookService.GetOrderBookSides(req.ExchangeName, req.Pair, opts)
+		if err == nil {
+			resp = newGetOrderBookResponse(orderBook)
+			version = orderBook.Version
+		}
+	}
+	switch err.(type) {
+	case nil:
+	case domain.OrderBookNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	case domain.OrderBookStale:
+		// 412 is left to conditional requests
+		httputils.ConflictError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.Header("Last-Modified", resp.UpdatedAt.UTC().Format(http.TimeFormat))
+	if version != 0 {
+		etag := orderBookRepresentationETag(version, reqQuery.representation())
+		ctx.Header("ETag", etag)
+		if ifNoneMatch := ctx.GetHeader("If-None-Match"); ifNoneMatch != "" && matchETag(ifNoneMatch, etag) {
+			ctx.Status(http.StatusNotModified)
+			return
+		}
+	}
+	if instrument != nil {
+		ctx.JSON(http.StatusOK, getFormattedOrderBookResponse{
+			getOrderBookResponse: resp,
+			Bids:                 instrument.FormatDepthOrders(resp.Bids),
+			Asks:                 instrument.FormatDepthOrders(resp.Asks),
+		})
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// representation returns the parameters shaping the levels of the response.
+// Point-in-time requests are left out as snapshots have no ETag.
+func (q *getOrderBookRequestQuery) representation() url.Values {
+	representation := url.Values{}
+	if q.Depth > 0 {
+		representation.Set("depth", strconv.Itoa(q.Depth))
+	}
+	if q.Side != "" {
+		representation.Set("side", string(q.Side))
+	}
+	if q.Group.IsPositive() {
+		representation.Set("group", q.Group.String())
+	}
+	if q.Precision != "" {
+		representation.Set("precision", q.Precision)
+	}
+	return representation
+}
+
+func newGetOrderBookResponse(orderBook *domain.OrderBook) getOrderBookResponse {
+	resp := getOrderBookResponse{
+		Bids:         orderBook.Bids,
+		Asks:         orderBook.Asks,
+		Sequence:     orderBook.Sequence,
+		UpdatedAt:    orderBook.UpdatedAt,
+		ExchangeTime: orderBook.ExchangeTime,
+		Stale:        orderBook.Stale,
+	}
+	if resp.Bids == nil {
+		resp.Bids = []domain.DepthOrder{}
+	}
+	if resp.Asks == nil {
+		resp.Asks = []domain.DepthOrder{}
+	}
+	return resp
+}
+
+// validateGroup rejects a negative price step, the step is parsed as a decimal
+// so that grouping by 0.1 never sees a binary approximation of it.
+func validateGroup(group decimal.Decimal) error {
+	if group.IsNegative() {
+		return errors.New("group should not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package orderbookcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getOrderBookSnapshotTimesRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+type getOrderBookSnapshotTimesRequestQuery struct {
+	From  *time.Time `form:"from" binding:"required"`
+	To    *time.Time `form:"to" binding:"required"`
+	Limit int        `form:"limit,default=1000" binding:"min=1,max=10000"`
+}
+
+type getOrderBookSnapshotTimesResponse struct {
+	Times []time.Time `json:"times"`
+}
+
+// getOrderBookSnapshotTimes godoc
+// @Summary List Order Book Snapshots
+// @Description Lists times of order book snapshots saved for a specific exchange and pair within [from, to] in ascending order.
+// @Tags OrderBook
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Param from query string true "Range start, RFC 3339"
+// @Param to query string true "Range end, RFC 3339"
+// @Param limit query int false "Maximum number of snapshots" default(1000)
+// @Success 200 {object} getOrderBookSnapshotTimesResponse "Snapshot times"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/pairs/{pair}/order-book/snapshots [get]
+func (c *OrderBookController) getOrderBookSnapshotTimes(ctx *gin.Context) {
+	var req getOrderBookSnapshotTimesRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqQuery getOrderBookSnapshotTimesRequestQuery
+	err = ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	snapshotTimes, err := c.orderBookService.GetOrderBookSnapshotTimes(
+		req.ExchangeName, req.Pair, *reqQuery.From, *reqQuery.To, reqQuery.Limit)
+	if err != nil {
+		httputils.InternalError(ctx)
+		return
+	}
+	if snapshotTimes == nil {
+		snapshotTimes = []time.Time{}
+	}
+
+	ctx.JSON(http.StatusOK, getOrderBookSnapshotTimesResponse{
+		Times: snapshotTimes,
+	})
+}
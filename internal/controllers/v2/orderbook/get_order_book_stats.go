@@ -0,0 +1,65 @@
+package orderbookcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getOrderBookStatsRequest struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+type getOrderBookStatsRequestQuery struct {
+	Levels int `form:"levels,default=5" binding:"min=1,max=1000"`
+}
+
+type getOrderBookStatsResponse struct {
+	Stats *domain.OrderBookStats `json:"stats"`
+}
+
+// getOrderBookStats godoc
+// @Summary Get Order Book Stats
+// @Description Computes best bid and ask, mid price, spread in absolute terms and in basis points, microprice and volume imbalance over the top levels of the stored order book. Metrics that need both sides are null if one of the sides is empty.
+// @Tags OrderBook
+// @Produce json
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Param levels query int false "Number of top levels per side used for imbalance" default(5)
+// @Success 200 {object} getOrderBookStatsResponse "Order Book stats"
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 404 {object} httputils.HTTPError "Order Book not found"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/pairs/{pair}/order-book/stats [get]
+func (c *OrderBookController) getOrderBookStats(ctx *gin.Context) {
+	var req getOrderBookStatsRequest
+	err := ctx.BindUri(&req)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqQuery getOrderBookStatsRequestQuery
+	err = ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	stats, err := c.orderBookService.GetOrderBookStats(req.ExchangeName, req.Pair, reqQuery.Levels)
+	switch err.(type) {
+	case nil:
+	case domain.OrderBookNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, getOrderBookStatsResponse{
+		Stats: stats,
+	})
+}
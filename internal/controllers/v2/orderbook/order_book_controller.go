@@ -0,0 +1,52 @@
+package orderbookcontroller
+
+import (
+	"market-info-storage/internal/domain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderBookController struct {
+	orderBookService OrderBookService
+}
+
+//go:generate mockery --name OrderBookService --filename order_book_service.go
+type OrderBookService interface {
+	SaveOrderBookSides(exchangeName, pair string, orderBook *domain.OrderBook) error
+	SaveOrderBookSidesIfMatch(exchangeName, pair string, orderBook *domain.OrderBook, versions []int64) error
+	SaveOrderBooks(items []domain.OrderBookBatchItem, strict bool) ([]domain.OrderBookBatchFailure, error)
+	GetOrderBookSides(exchangeName, pair string, opts domain.OrderBookReadOptions) (*domain.OrderBook, error)
+	GetInstrument(exchangeName, pair string) (*domain.Instrument, error)
+	ApplyOrderBookDelta(exchangeName, pair string, delta *domain.OrderBookDelta) error
+	GetOrderBookAt(exchangeName, pair string, at time.Time, opts domain.OrderBookReadOptions) (*domain.OrderBookSnapshot, error)
+	GetOrderBookStats(exchangeName, pair string, levels int) (*domain.OrderBookStats, error)
+	EstimateMarketImpact(exchangeName, pair string, side domain.OrderSide, baseQty, quoteQty float64) (*domain.MarketImpact, error)
+	GetConsolidatedOrderBook(pair string, exchangeNames []string, opts domain.OrderBookReadOptions) (*domain.ConsolidatedOrderBook, error)
+	GetOrderBookSnapshotTimes(exchangeName, pair string, from, to time.Time, limit int) ([]time.Time, error)
+	DeleteOrderBook(exchangeName, pair string) error
+	DeleteExchangeOrderBooks(exchangeName string) (int64, error)
+	SubscribeOrderBooks(keys []domain.OrderBookKey, conflate bool) (*domain.OrderBookSubscription, []domain.OrderBookUpdate, error)
+}
+
+func NewOrderBookController(orderBookService OrderBookService) *OrderBookController {
+	return &OrderBookController{
+		orderBookService: orderBookService,
+	}
+}
+
+func (c *OrderBookController) RegisterRoutes(engine *gin.Engine) {
+	orderBookGroup := engine.Group("/api/v2/exchanges/:exchange/pairs/:pair/order-book")
+	orderBookGroup.PUT("", c.saveOrderBook)
+	orderBookGroup.GET("", c.getOrderBook)
+	orderBookGroup.PATCH("", c.applyOrderBookDelta)
+	orderBookGroup.DELETE("", c.deleteOrderBook)
+	orderBookGroup.GET("/snapshots", c.getOrderBookSnapshotTimes)
+	orderBookGroup.GET("/stats", c.getOrderBookStats)
+	orderBookGroup.GET("/impact", c.estimateMarketImpact)
+
+	engine.PUT("/api/v2/order-books", c.saveOrderBooks)
+	engine.GET("/api/v2/order-books/stream", c.streamOrderBooks)
+	engine.DELETE("/api/v2/exchanges/:exchange/order-books", c.deleteExchangeOrderBooks)
+	engine.GET("/api/v2/pairs/:pair/order-book", c.getConsolidatedOrderBook)
+}
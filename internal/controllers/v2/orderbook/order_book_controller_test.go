@@ -0,0 +1,1427 @@
+package orderbookcontroller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/controllers/v2/orderbook/mocks"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveOrderBook(t *testing.T) {
+	exchange := "bybit"
+	pair := "MATIC_USDT"
+	orderBook := &domain.OrderBook{
+		Bids: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")},
+			{Price: decimal.RequireFromString("0.52"), BaseQty: decimal.RequireFromString("3.2")},
+			{Price: decimal.RequireFromString("0.51"), BaseQty: decimal.RequireFromString("0.7")},
+		},
+		Asks: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")},
+		},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SaveOrderBookSides", exchange, pair, orderBook).Return(nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book", exchange, pair)
+	reqBodyReader := new(bytes.Buffer)
+	err := json.NewEncoder(reqBodyReader).Encode(saveOrderBookRequestBody{
+		Bids: orderBook.Bids,
+		Asks: orderBook.Asks,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, url, reqBodyReader)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestSaveOrderBookWrongFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exchange string
+		pair     string
+		body     string
+	}{
+		{
+			name:     "AbsentAsks",
+			exchange: "bybit",
+			pair:     "MATIC_USDT",
+			body:     `{"bids": [{"price": 0.53, "baseQty": 1.5}]}`,
+		},
+		{
+			name:     "NotAnArray",
+			exchange: "bybit",
+			pair:     "MATIC_USDT",
+			body:     `{"bids": {"price": 0.53, "baseQty": 1.5}, "asks": []}`,
+		},
+		{
+			name:     "EmptyExchange",
+			exchange: "",
+			pair:     "MATIC_USDT",
+			body:     `{"bids": [], "asks": []}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := mocks.NewOrderBookService(t)
+			controller := NewOrderBookController(service)
+
+			url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book", tc.exchange, tc.pair)
+			reqBodyReader := strings.NewReader(tc.body)
+			req := httptest.NewRequest(http.MethodPut, url, reqBodyReader)
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+		})
+	}
+}
+
+func TestSaveInvalidOrderBook(t *testing.T) {
+	exchange := "bybit"
+	pair := "MATIC_USDT"
+	orderBook := &domain.OrderBook{
+		Bids: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.55"), BaseQty: decimal.RequireFromString("1.5")},
+		},
+		Asks: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("0")},
+		},
+	}
+	violations := []domain.LevelViolation{
+		{Side: domain.SideAsk, Index: 0, Reason: domain.ReasonQtyNotPositive},
+		{Side: domain.SideBid, Index: 0, Reason: domain.ReasonCrossedBook},
+		{Side: domain.SideAsk, Index: 0, Reason: domain.ReasonCrossedBook},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SaveOrderBookSides", exchange, pair, orderBook).Return(domain.OrderBookInvalid{Violations: violations})
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book", exchange, pair)
+	reqBodyReader := new(bytes.Buffer)
+	err := json.NewEncoder(reqBodyReader).Encode(saveOrderBookRequestBody{
+		Bids: orderBook.Bids,
+		Asks: orderBook.Asks,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, url, reqBodyReader)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody httputils.HTTPValidationError
+	err = json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.True(t, reflect.DeepEqual(violations, respBody.Violations))
+}
+
+func TestSaveOrderBookOfUnavailableExchange(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name:   "NotRegistered",
+			err:    domain.ExchangeNotFound{Message: `exchange "bybitt" is not registered`},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Disabled",
+			err:    domain.ExchangeDisabled{Message: `exchange "bybitt" is disabled`},
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			orderBook := &domain.OrderBook{
+				Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}},
+				Asks: []domain.DepthOrder{{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")}},
+			}
+			service := mocks.NewOrderBookService(t)
+			service.On("SaveOrderBookSides", "bybitt", "MATIC_USDT", orderBook).Return(tc.err)
+			controller := NewOrderBookController(service)
+
+			body := `{"bids": [{"price": "0.53", "baseQty": "1.5"}], "asks": [{"price": "0.54", "baseQty": "1.1"}]}`
+			req := httptest.NewRequest(http.MethodPut, "/api/v2/exchanges/bybitt/pairs/MATIC_USDT/order-book", strings.NewReader(body))
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			var respBody httputils.HTTPError
+			err := json.Unmarshal(w.Body.Bytes(), &respBody)
+			require.NoError(t, err)
+			require.Equal(t, tc.status, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+			require.Equal(t, tc.err.Error(), respBody.Message)
+		})
+	}
+}
+
+func TestSaveOrderBookChecksum(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{
+			name:   "Match",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Mismatch",
+			err:    domain.OrderBookChecksumMismatch{Message: "checksum -1881014294 does not match checksum 1 computed with okx algorithm"},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "UnknownAlgorithm",
+			err:    domain.ChecksumAlgorithmUnknown{Message: `unknown checksum algorithm "okx"`},
+			status: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			orderBook := &domain.OrderBook{
+				Bids:     []domain.DepthOrder{{Price: decimal.RequireFromString("3366.1"), BaseQty: decimal.RequireFromString("7")}},
+				Asks:     []domain.DepthOrder{{Price: decimal.RequireFromString("3366.8"), BaseQty: decimal.RequireFromString("9")}},
+				Checksum: &domain.OrderBookChecksum{Algorithm: "okx", Value: -1881014294},
+			}
+			service := mocks.NewOrderBookService(t)
+			service.On("SaveOrderBookSides", "okx", "ETH_USDT", orderBook).Return(tc.err)
+			controller := NewOrderBookController(service)
+
+			body := `{"bids": [{"price": "3366.1", "baseQty": "7"}], "asks": [{"price": "3366.8", "baseQty": "9"}], "checksum": -1881014294, "checksumAlgorithm": "okx"}`
+			req := httptest.NewRequest(http.MethodPut, "/api/v2/exchanges/okx/pairs/ETH_USDT/order-book", strings.NewReader(body))
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.status, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+		})
+	}
+}
+
+func TestSaveOrderBookIfMatch(t *testing.T) {
+	orderBook := &domain.OrderBook{
+		Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}},
+		Asks: []domain.DepthOrder{{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")}},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SaveOrderBookSidesIfMatch", "bybit", "MATIC_USDT", orderBook, []int64{7, 9}).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(*domain.OrderBook).Version = 12
+		}).
+		Return(nil)
+	controller := NewOrderBookController(service)
+
+	reqBodyReader := new(bytes.Buffer)
+	err := json.NewEncoder(reqBodyReader).Encode(saveOrderBookRequestBody{
+		Bids: orderBook.Bids,
+		Asks: orderBook.Asks,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/exchanges/bybit/pairs/MATIC_USDT/order-book", reqBodyReader)
+	req.Header.Set("If-Match", `"7", W/"8", "9"`)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, `"12"`, w.Header().Get("ETag"))
+}
+
+func TestSaveOrderBookVersionMismatch(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ifMatch string
+		// callsService is unset when no tag can match and the request is rejected right away
+		callsService bool
+	}{
+		{
+			name:         "ChangedVersion",
+			ifMatch:      `"7"`,
+			callsService: true,
+		},
+		{
+			name:         "AbsentOrderBook",
+			ifMatch:      "*",
+			callsService: true,
+		},
+		{
+			name:    "WeakETag",
+			ifMatch: `W/"7"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := mocks.NewOrderBookService(t)
+			if tc.callsService {
+				service.On("SaveOrderBookSidesIfMatch", "bybit", "MATIC_USDT", mock.Anything, mock.Anything).
+					Return(domain.OrderBookVersionMismatch{Message: "order book version does not match"})
+			}
+			controller := NewOrderBookController(service)
+
+			body := `{"bids": [{"price": 0.53, "baseQty": 1.5}], "asks": []}`
+			req := httptest.NewRequest(http.MethodPut, "/api/v2/exchanges/bybit/pairs/MATIC_USDT/order-book", strings.NewReader(body))
+			req.Header.Set("If-Match", tc.ifMatch)
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusPreconditionFailed, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+		})
+	}
+}
+
+func TestSaveOrderBooks(t *testing.T) {
+	reqBody := saveOrderBooksRequestBody{
+		OrderBooks: []saveOrderBooksRequestItem{
+			{
+				ExchangeName: "bybit",
+				Pair:         "MATIC_USDT",
+				Bids:         []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}},
+				Asks:         []domain.DepthOrder{{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")}},
+			},
+			{
+				ExchangeName: "bybit",
+				Pair:         "SOL_USDT",
+				Bids:         []domain.DepthOrder{{Price: decimal.RequireFromString("150"), BaseQty: decimal.RequireFromString("2")}},
+				Asks:         []domain.DepthOrder{{Price: decimal.RequireFromString("149"), BaseQty: decimal.RequireFromString("1")}},
+			},
+		},
+	}
+	items := []domain.OrderBookBatchItem{
+		{ExchangeName: "bybit", Pair: "MATIC_USDT", OrderBook: &domain.OrderBook{
+			Bids: reqBody.OrderBooks[0].Bids,
+			Asks: reqBody.OrderBooks[0].Asks,
+		}},
+		{ExchangeName: "bybit", Pair: "SOL_USDT", OrderBook: &domain.OrderBook{
+			Bids: reqBody.OrderBooks[1].Bids,
+			Asks: reqBody.OrderBooks[1].Asks,
+		}},
+	}
+	failures := []domain.OrderBookBatchFailure{{
+		Index:        1,
+		ExchangeName: "bybit",
+		Pair:         "SOL_USDT",
+		Error:        "order book has 1 violations",
+		Violations:   []domain.LevelViolation{{Side: domain.SideBid, Index: 0, Reason: domain.ReasonCrossedBook}},
+	}}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SaveOrderBooks", items, false).Return(failures, nil)
+	controller := NewOrderBookController(service)
+
+	reqBodyReader := new(bytes.Buffer)
+	err := json.NewEncoder(reqBodyReader).Encode(reqBody)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/order-books", reqBodyReader)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody saveOrderBooksResponse
+	err = json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, 1, respBody.Saved)
+	require.Equal(t, failures, respBody.Failures)
+}
+
+func TestSaveOrderBooksStrict(t *testing.T) {
+	failures := []domain.OrderBookBatchFailure{{
+		Index:        0,
+		ExchangeName: "bybit",
+		Pair:         "SOL_USDT",
+		Error:        "order book has 1 violations",
+		Violations:   []domain.LevelViolation{{Side: domain.SideAsk, Index: 0, Reason: domain.ReasonQtyNotPositive}},
+	}}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SaveOrderBooks", mock.Anything, true).Return(failures, nil)
+	controller := NewOrderBookController(service)
+
+	reqBody := `{"orderBooks": [{"exchange": "bybit", "pair": "SOL_USDT", "bids": [], "asks": [{"price": 150, "baseQty": 0}]}]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v2/order-books?strict=true", strings.NewReader(reqBody))
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody saveOrderBooksResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, 0, respBody.Saved)
+	require.Equal(t, failures, respBody.Failures)
+}
+
+func TestSaveOrderBooksWrongFormat(t *testing.T) {
+	testCases := []struct {
+		name    string
+		reqBody string
+	}{
+		{name: "empty batch", reqBody: `{"orderBooks": []}`},
+		{name: "no exchange", reqBody: `{"orderBooks": [{"pair": "SOL_USDT", "bids": [], "asks": []}]}`},
+		{name: "no asks", reqBody: `{"orderBooks": [{"exchange": "bybit", "pair": "SOL_USDT", "bids": []}]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := mocks.NewOrderBookService(t)
+			controller := NewOrderBookController(service)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v2/order-books", strings.NewReader(tc.reqBody))
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestGetOrderBook(t *testing.T) {
+	exchange := "binance"
+	pair := "SOL_USDT"
+	orderBook := &domain.OrderBook{
+		Bids: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")},
+		},
+		Asks: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")},
+			{Price: decimal.RequireFromString("0.55"), BaseQty: decimal.RequireFromString("2.4")},
+		},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookSides", exchange, pair, domain.OrderBookReadOptions{}).Return(orderBook, nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book", exchange, pair)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getOrderBookResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.True(t, reflect.DeepEqual(orderBook.Bids, respBody.Bids))
+	require.True(t, reflect.DeepEqual(orderBook.Asks, respBody.Asks))
+}
+
+func TestOrderBookDecimalPrices(t *testing.T) {
+	orderBook := &domain.OrderBook{
+		Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("0.010782342"), BaseQty: decimal.RequireFromString("24")}},
+		Asks: []domain.DepthOrder{{Price: decimal.RequireFromString("0.010782343"), BaseQty: decimal.RequireFromString("11.5")}},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SaveOrderBookSides", "bybit", "MATIC_USDT", orderBook).Return(nil)
+	service.On("GetOrderBookSides", "bybit", "MATIC_USDT", domain.OrderBookReadOptions{}).Return(orderBook, nil)
+	controller := NewOrderBookController(service)
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+
+	url := "/api/v2/exchanges/bybit/pairs/MATIC_USDT/order-book"
+	body := `{"bids": [{"price": "0.010782342", "baseQty": 24}], "asks": [{"price": 0.010782343, "baseQty": "11.5"}]}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, url, strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Contains(t, w.Body.String(), `"bids":[{"price":"0.010782342","baseQty":"24"}]`)
+	require.Contains(t, w.Body.String(), `"asks":[{"price":"0.010782343","baseQty":"11.5"}]`)
+}
+
+func TestGetOrderBookTopOfSide(t *testing.T) {
+	exchange := "binance"
+	pair := "SOL_USDT"
+	opts := domain.OrderBookReadOptions{Depth: 1, Side: domain.SideAsk}
+	orderBook := &domain.OrderBook{
+		Asks: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")},
+		},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookSides", exchange, pair, opts).Return(orderBook, nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book?depth=1&side=ask", exchange, pair)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getOrderBookResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Empty(t, respBody.Bids)
+	require.True(t, reflect.DeepEqual(orderBook.Asks, respBody.Asks))
+}
+
+func TestGetGroupedOrderBook(t *testing.T) {
+	exchange := "binance"
+	pair := "SOL_USDT"
+	opts := domain.OrderBookReadOptions{Depth: 10, Group: decimal.RequireFromString("0.1")}
+	orderBook := &domain.OrderBook{
+		Bids: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.5"), BaseQty: decimal.RequireFromString("2.6")},
+		},
+		Asks: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.6"), BaseQty: decimal.RequireFromString("1.1")},
+			{Price: decimal.RequireFromString("0.7"), BaseQty: decimal.RequireFromString("3")},
+		},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookSides", exchange, pair, opts).Return(orderBook, nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book?depth=10&group=0.1", exchange, pair)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getOrderBookResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.True(t, reflect.DeepEqual(orderBook.Bids, respBody.Bids))
+	require.True(t, reflect.DeepEqual(orderBook.Asks, respBody.Asks))
+}
+
+func TestGetOrderBookWrongQuery(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "NegativeDepth", query: "depth=-1"},
+		{name: "UnknownSide", query: "side=buy"},
+		{name: "MalformedAt", query: "at=yesterday"},
+		{name: "NegativeGroup", query: "group=-0.1"},
+		{name: "MalformedGroup", query: "group=0.1x"},
+		{name: "UnknownPrecision", query: "precision=exchange"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := mocks.NewOrderBookService(t)
+			controller := NewOrderBookController(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book?"+tc.query, nil)
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+		})
+	}
+}
+
+func TestGetNonExistentOrderBook(t *testing.T) {
+	exchange := "binance"
+	pair := "SOL_USDT"
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookSides", exchange, pair, domain.OrderBookReadOptions{}).Return(nil, domain.OrderBookNotFound{})
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book", exchange, pair)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetOrderBookFreshness(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	exchangeTime := updatedAt.Add(-50 * time.Millisecond)
+	orderBook := &domain.OrderBook{
+		Bids:         []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}},
+		Asks:         []domain.DepthOrder{{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")}},
+		UpdatedAt:    updatedAt,
+		ExchangeTime: &exchangeTime,
+		Stale:        true,
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookSides", "binance", "SOL_USDT", domain.OrderBookReadOptions{MaxAge: time.Minute}).
+		Return(orderBook, nil)
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book?max_age=1m", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getOrderBookResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, "Sat, 01 Jun 2024 12:00:00 GMT", w.Header().Get("Last-Modified"))
+	require.True(t, updatedAt.Equal(respBody.UpdatedAt))
+	require.NotNil(t, respBody.ExchangeTime)
+	require.True(t, exchangeTime.Equal(*respBody.ExchangeTime))
+	require.True(t, respBody.Stale)
+}
+
+func TestGetStaleOrderBook(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookSides", "binance", "SOL_USDT", domain.OrderBookReadOptions{MaxAge: 5 * time.Second}).
+		Return(nil, domain.OrderBookStale{Message: "order book was updated 1m0s ago, max age is 5s"})
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book?max_age=5s", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestGetOrderBookInstrumentPrecision(t *testing.T) {
+	orderBook := &domain.OrderBook{
+		Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}},
+		Asks: []domain.DepthOrder{{Price: decimal.RequireFromString("0.5412"), BaseQty: decimal.RequireFromString("2")}},
+	}
+	instrument := &domain.Instrument{
+		ExchangeName: "binance",
+		Pair:         "SOL_USDT",
+		TickSize:     decimal.RequireFromString("0.0010"),
+		QtyStep:      decimal.RequireFromString("0.01"),
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetInstrument", "binance", "SOL_USDT").Return(instrument, nil)
+	service.On("GetOrderBookSides", "binance", "SOL_USDT", domain.OrderBookReadOptions{}).Return(orderBook, nil)
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book?precision=instrument", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Contains(t, w.Body.String(), `"bids":[{"price":"0.530","baseQty":"1.50"}]`)
+	require.Contains(t, w.Body.String(), `"asks":[{"price":"0.5412","baseQty":"2.00"}]`)
+}
+
+func TestGetOrderBookPrecisionWithoutInstrument(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	service.On("GetInstrument", "binance", "SOL_USDT").Return(nil, domain.InstrumentNotFound{})
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book?precision=instrument", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetOrderBookNotModified(t *testing.T) {
+	testCases := []struct {
+		name        string
+		query       string
+		opts        domain.OrderBookReadOptions
+		ifNoneMatch string
+		etag        string
+		status      int
+	}{
+		{
+			name:        "SameVersion",
+			ifNoneMatch: `"5", "12"`,
+			etag:        `"12"`,
+			status:      http.StatusNotModified,
+		},
+		{
+			name:        "WeakETag",
+			ifNoneMatch: `W/"12"`,
+			etag:        `"12"`,
+			status:      http.StatusNotModified,
+		},
+		{
+			name:        "Any",
+			ifNoneMatch: "*",
+			etag:        `"12"`,
+			status:      http.StatusNotModified,
+		},
+		{
+			name:        "ChangedVersion",
+			ifNoneMatch: `"5"`,
+			etag:        `"12"`,
+			status:      http.StatusOK,
+		},
+		{
+			name:        "SameRepresentation",
+			query:       "?side=bid&depth=10&group=0.10",
+			opts:        domain.OrderBookReadOptions{Depth: 10, Side: domain.SideBid, Group: decimal.RequireFromString("0.10")},
+			ifNoneMatch: `"12-depth=10&group=0.1&side=bid"`,
+			etag:        `"12-depth=10&group=0.1&side=bid"`,
+			status:      http.StatusNotModified,
+		},
+		{
+			name:        "OtherRepresentation",
+			query:       "?depth=10",
+			opts:        domain.OrderBookReadOptions{Depth: 10},
+			ifNoneMatch: `"12", "12-depth=20"`,
+			etag:        `"12-depth=10"`,
+			status:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			orderBook := &domain.OrderBook{
+				Bids:    []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}},
+				Asks:    []domain.DepthOrder{{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")}},
+				Version: 12,
+			}
+			service := mocks.NewOrderBookService(t)
+			service.On("GetOrderBookSides", "binance", "SOL_USDT", tc.opts).Return(orderBook, nil)
+			controller := NewOrderBookController(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book"+tc.query, nil)
+			req.Header.Set("If-None-Match", tc.ifNoneMatch)
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.status, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+			require.Equal(t, tc.etag, w.Header().Get("ETag"))
+			if tc.status == http.StatusNotModified {
+				require.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestApplyOrderBookDelta(t *testing.T) {
+	exchange := "bybit"
+	pair := "MATIC_USDT"
+	sequence := int64(42)
+	delta := &domain.OrderBookDelta{
+		Sequence: sequence,
+		Bids: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("0")},
+		},
+		Asks: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("2.5")},
+		},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("ApplyOrderBookDelta", exchange, pair, delta).Return(nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book", exchange, pair)
+	reqBodyReader := new(bytes.Buffer)
+	err := json.NewEncoder(reqBodyReader).Encode(applyOrderBookDeltaRequestBody{
+		Sequence: &sequence,
+		Bids:     delta.Bids,
+		Asks:     delta.Asks,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPatch, url, reqBodyReader)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestApplyOrderBookDeltaWithoutSequence(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	controller := NewOrderBookController(service)
+
+	url := "/api/v2/exchanges/bybit/pairs/MATIC_USDT/order-book"
+	reqBodyReader := strings.NewReader(`{"bids": [{"price": 0.53, "baseQty": 0}]}`)
+	req := httptest.NewRequest(http.MethodPatch, url, reqBodyReader)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestApplyOrderBookDeltaResyncRequired(t *testing.T) {
+	exchange := "bybit"
+	pair := "MATIC_USDT"
+	sequence := int64(44)
+
+	service := mocks.NewOrderBookService(t)
+	service.On("ApplyOrderBookDelta", exchange, pair, mock.Anything).
+		Return(domain.OrderBookResyncRequired{Message: "expected delta sequence 43, got 44"})
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book", exchange, pair)
+	reqBodyReader := new(bytes.Buffer)
+	err := json.NewEncoder(reqBodyReader).Encode(applyOrderBookDeltaRequestBody{Sequence: &sequence})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPatch, url, reqBodyReader)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestApplyOrderBookDeltaChecksumMismatch(t *testing.T) {
+	exchange := "kraken"
+	pair := "BTC_USD"
+	sequence := int64(43)
+	checksum := int64(974947235)
+	delta := &domain.OrderBookDelta{
+		Sequence: sequence,
+		Asks: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("67000.1"), BaseQty: decimal.RequireFromString("0.5")},
+		},
+		Checksum: &domain.OrderBookChecksum{Value: checksum},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("ApplyOrderBookDelta", exchange, pair, delta).
+		Return(domain.OrderBookChecksumMismatch{Message: "checksum 974947235 does not match checksum 1 computed with kraken algorithm"})
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book", exchange, pair)
+	reqBodyReader := new(bytes.Buffer)
+	err := json.NewEncoder(reqBodyReader).Encode(applyOrderBookDeltaRequestBody{
+		Sequence: &sequence,
+		Asks:     delta.Asks,
+		Checksum: &checksum,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPatch, url, reqBodyReader)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestGetOrderBookAt(t *testing.T) {
+	exchange := "binance"
+	pair := "SOL_USDT"
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	snapshot := &domain.OrderBookSnapshot{
+		Time: at.Add(-time.Second),
+		OrderBook: &domain.OrderBook{
+			Bids: []domain.DepthOrder{
+				{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")},
+			},
+			Asks: []domain.DepthOrder{
+				{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")},
+			},
+		},
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookAt", exchange, pair, mock.MatchedBy(at.Equal), domain.OrderBookReadOptions{}).Return(snapshot, nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book?at=%s", exchange, pair, at.Format(time.RFC3339))
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getOrderBookResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.True(t, reflect.DeepEqual(snapshot.OrderBook.Bids, respBody.Bids))
+	require.True(t, reflect.DeepEqual(snapshot.OrderBook.Asks, respBody.Asks))
+	require.True(t, snapshot.Time.Equal(*respBody.Time))
+}
+
+func TestGetOrderBookSnapshotTimes(t *testing.T) {
+	exchange := "binance"
+	pair := "SOL_USDT"
+	from := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	snapshotTimes := []time.Time{
+		from.Add(time.Minute),
+		from.Add(2 * time.Minute),
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookSnapshotTimes", exchange, pair, mock.MatchedBy(from.Equal), mock.MatchedBy(to.Equal), 1000).
+		Return(snapshotTimes, nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book/snapshots?from=%s&to=%s",
+		exchange, pair, from.Format(time.RFC3339), to.Format(time.RFC3339))
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getOrderBookSnapshotTimesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Len(t, respBody.Times, len(snapshotTimes))
+	for i := range snapshotTimes {
+		require.True(t, snapshotTimes[i].Equal(respBody.Times[i]))
+	}
+}
+
+func TestGetOrderBookSnapshotTimesWithoutRange(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book/snapshots", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestGetOrderBookStats(t *testing.T) {
+	exchange := "binance"
+	pair := "SOL_USDT"
+	orderBook := &domain.OrderBook{
+		Bids: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("99"), BaseQty: decimal.RequireFromString("3")},
+			{Price: decimal.RequireFromString("98"), BaseQty: decimal.RequireFromString("1")},
+		},
+		Asks: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("101"), BaseQty: decimal.RequireFromString("1")},
+			{Price: decimal.RequireFromString("102"), BaseQty: decimal.RequireFromString("3")},
+		},
+	}
+	stats := orderBook.Stats(1)
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookStats", exchange, pair, 1).Return(&stats, nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book/stats?levels=1", exchange, pair)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getOrderBookStatsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, 100.0, *respBody.Stats.Mid)
+	require.Equal(t, 2.0, *respBody.Stats.Spread)
+	require.Equal(t, 200.0, *respBody.Stats.SpreadBps)
+	require.Equal(t, 100.5, *respBody.Stats.Microprice)
+	require.Equal(t, 0.5, *respBody.Stats.Imbalance)
+}
+
+func TestGetNonExistentOrderBookStats(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	service.On("GetOrderBookStats", "binance", "SOL_USDT", 5).Return(nil, domain.OrderBookNotFound{})
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book/stats", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEstimateMarketImpact(t *testing.T) {
+	exchange := "binance"
+	pair := "SOL_USDT"
+	orderBook := &domain.OrderBook{
+		Bids: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("99"), BaseQty: decimal.RequireFromString("1")},
+		},
+		Asks: []domain.DepthOrder{
+			{Price: decimal.RequireFromString("101"), BaseQty: decimal.RequireFromString("1")},
+			{Price: decimal.RequireFromString("103"), BaseQty: decimal.RequireFromString("1")},
+		},
+	}
+	impact := orderBook.EstimateMarketImpact(domain.OrderSideBuy, 3, 0)
+
+	service := mocks.NewOrderBookService(t)
+	service.On("EstimateMarketImpact", exchange, pair, domain.OrderSideBuy, 3.0, 0.0).Return(&impact, nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/exchanges/%s/pairs/%s/order-book/impact?side=buy&base-qty=3", exchange, pair)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody estimateMarketImpactResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, 2.0, respBody.Impact.FilledBaseQty)
+	require.Equal(t, 102.0, *respBody.Impact.VWAPPrice)
+	require.Contains(t, w.Body.String(), `"worstPrice":"103"`)
+	require.True(t, decimal.RequireFromString("103").Equal(*respBody.Impact.WorstPrice))
+	require.Equal(t, 2, respBody.Impact.LevelsConsumed)
+	require.Equal(t, 200.0, *respBody.Impact.SlippageBps)
+	require.Equal(t, 1.0, *respBody.Impact.UnfilledBaseQty)
+}
+
+func TestEstimateMarketImpactWrongQuery(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "AbsentSide", query: "base-qty=1"},
+		{name: "UnknownSide", query: "side=bid&base-qty=1"},
+		{name: "AbsentSize", query: "side=buy"},
+		{name: "BothSizes", query: "side=buy&base-qty=1&quote-qty=100"},
+		{name: "NegativeSize", query: "side=sell&quote-qty=-100"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := mocks.NewOrderBookService(t)
+			controller := NewOrderBookController(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book/impact?"+tc.query, nil)
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+		})
+	}
+}
+
+func TestGetConsolidatedOrderBook(t *testing.T) {
+	pair := "SOL_USDT"
+	exchanges := []string{"binance", "bybit"}
+	opts := domain.OrderBookReadOptions{Depth: 5}
+	orderBook := domain.ConsolidateOrderBooks(map[string]*domain.OrderBook{
+		"binance": {
+			Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}},
+			Asks: []domain.DepthOrder{{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")}},
+		},
+		"bybit": {
+			Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("0.5")}},
+			Asks: []domain.DepthOrder{{Price: decimal.RequireFromString("0.55"), BaseQty: decimal.RequireFromString("2")}},
+		},
+	}, opts)
+
+	service := mocks.NewOrderBookService(t)
+	service.On("GetConsolidatedOrderBook", pair, exchanges, opts).Return(orderBook, nil)
+	controller := NewOrderBookController(service)
+
+	url := fmt.Sprintf("/api/v2/pairs/%s/order-book?exchange=binance&exchange=bybit&depth=5", pair)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody getConsolidatedOrderBookResponse
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+	require.Equal(t, []domain.ConsolidatedDepthOrder{
+		{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("2"), Exchanges: map[string]decimal.Decimal{"binance": decimal.RequireFromString("1.5"), "bybit": decimal.RequireFromString("0.5")}},
+	}, respBody.Bids)
+	require.Equal(t, []domain.ConsolidatedDepthOrder{
+		{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1"), Exchanges: map[string]decimal.Decimal{"binance": decimal.RequireFromString("1.1")}},
+		{Price: decimal.RequireFromString("0.55"), BaseQty: decimal.RequireFromString("2"), Exchanges: map[string]decimal.Decimal{"bybit": decimal.RequireFromString("2")}},
+	}, respBody.Asks)
+}
+
+func TestGetConsolidatedOrderBookOfUnknownPair(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	service.On("GetConsolidatedOrderBook", "SOL_USDT", []string(nil), domain.OrderBookReadOptions{}).
+		Return(nil, domain.OrderBookNotFound{})
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/pairs/SOL_USDT/order-book", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteOrderBook(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	service.On("DeleteOrderBook", "binance", "SOL_USDT").Return(nil)
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestDeleteNonExistentOrderBook(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	service.On("DeleteOrderBook", "binance", "SOL_USDT").Return(domain.OrderBookNotFound{Message: "order book not found"})
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/exchanges/binance/pairs/SOL_USDT/order-book", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	var respBody httputils.HTTPError
+	err := json.Unmarshal(w.Body.Bytes(), &respBody)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, "order book not found", respBody.Message)
+}
+
+func TestDeleteExchangeOrderBooks(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	service.On("DeleteExchangeOrderBooks", "binance").Return(int64(3), nil)
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/exchanges/binance/order-books", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code, fmt.Sprintf("response body: %s", w.Body.String()))
+}
+
+func TestDeleteOrderBooksOfUnknownExchange(t *testing.T) {
+	service := mocks.NewOrderBookService(t)
+	service.On("DeleteExchangeOrderBooks", "unknown").
+		Return(int64(0), domain.OrderBookNotFound{Message: "no order books found for the exchange"})
+	controller := NewOrderBookController(service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v2/exchanges/unknown/order-books", nil)
+
+	w := httptest.NewRecorder()
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestStreamOrderBooks(t *testing.T) {
+	keys := []domain.OrderBookKey{{ExchangeName: "binance", Pair: "SOL_USDT"}}
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	hub := domain.NewOrderBookHub(16)
+	sub := hub.Subscribe(keys, false)
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SubscribeOrderBooks", keys, false).Return(sub, []domain.OrderBookUpdate{{
+		ExchangeName: "binance",
+		Pair:         "SOL_USDT",
+		OrderBook: &domain.OrderBook{
+			Bids:      []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}, {Price: decimal.RequireFromString("0.52"), BaseQty: decimal.RequireFromString("2")}},
+			Asks:      []domain.DepthOrder{{Price: decimal.RequireFromString("0.54"), BaseQty: decimal.RequireFromString("1.1")}},
+			UpdatedAt: updatedAt,
+		},
+	}}, nil)
+	controller := NewOrderBookController(service)
+
+	conn := dialOrderBookStream(t, controller, "book=binance:SOL_USDT&depth=1")
+
+	var msg orderBookStreamMessage
+	err := conn.ReadJSON(&msg)
+	require.NoError(t, err)
+	require.Equal(t, "snapshot", msg.Type)
+	require.Equal(t, []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1.5")}}, msg.Bids)
+
+	hub.PublishOrderBook("binance", "SOL_USDT", &domain.OrderBook{
+		Bids:      []domain.DepthOrder{{Price: decimal.RequireFromString("0.55"), BaseQty: decimal.RequireFromString("1")}},
+		Asks:      []domain.DepthOrder{{Price: decimal.RequireFromString("0.56"), BaseQty: decimal.RequireFromString("3")}},
+		UpdatedAt: updatedAt.Add(time.Second),
+	})
+	err = conn.ReadJSON(&msg)
+	require.NoError(t, err)
+	require.Equal(t, "update", msg.Type)
+	require.Equal(t, "binance", msg.ExchangeName)
+	require.Equal(t, "SOL_USDT", msg.Pair)
+	require.Equal(t, []domain.DepthOrder{{Price: decimal.RequireFromString("0.55"), BaseQty: decimal.RequireFromString("1")}}, msg.Bids)
+	require.Equal(t, []domain.DepthOrder{{Price: decimal.RequireFromString("0.56"), BaseQty: decimal.RequireFromString("3")}}, msg.Asks)
+}
+
+func TestStreamOrderBooksSkipsPublishedVersions(t *testing.T) {
+	keys := []domain.OrderBookKey{{ExchangeName: "binance", Pair: "SOL_USDT"}}
+	hub := domain.NewOrderBookHub(16)
+	sub := hub.Subscribe(keys, false)
+	hub.PublishOrderBook("binance", "SOL_USDT", &domain.OrderBook{
+		Bids:    []domain.DepthOrder{{Price: decimal.RequireFromString("0.51"), BaseQty: decimal.RequireFromString("1")}},
+		Version: 1,
+	})
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SubscribeOrderBooks", keys, false).Return(sub, []domain.OrderBookUpdate{{
+		ExchangeName: "binance",
+		Pair:         "SOL_USDT",
+		OrderBook:    &domain.OrderBook{Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("0.52"), BaseQty: decimal.RequireFromString("1")}}, Version: 2},
+	}}, nil)
+	controller := NewOrderBookController(service)
+
+	conn := dialOrderBookStream(t, controller, "book=binance:SOL_USDT")
+
+	var msg orderBookStreamMessage
+	err := conn.ReadJSON(&msg)
+	require.NoError(t, err)
+	require.Equal(t, "snapshot", msg.Type)
+	require.Equal(t, []domain.DepthOrder{{Price: decimal.RequireFromString("0.52"), BaseQty: decimal.RequireFromString("1")}}, msg.Bids)
+
+	hub.PublishOrderBook("binance", "SOL_USDT", &domain.OrderBook{
+		Bids:    []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1")}},
+		Version: 3,
+	})
+	err = conn.ReadJSON(&msg)
+	require.NoError(t, err)
+	require.Equal(t, "update", msg.Type)
+	require.Equal(t, []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1")}}, msg.Bids)
+}
+
+func TestStreamOrderBooksConflation(t *testing.T) {
+	keys := []domain.OrderBookKey{{ExchangeName: "binance", Pair: "SOL_USDT"}}
+	hub := domain.NewOrderBookHub(1)
+	sub := hub.Subscribe(keys, true)
+	for i := 1; i <= 3; i++ {
+		hub.PublishOrderBook("binance", "SOL_USDT", &domain.OrderBook{
+			Bids: []domain.DepthOrder{{Price: decimal.NewFromFloat(0.5 + float64(i)/100), BaseQty: decimal.RequireFromString("1")}},
+		})
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SubscribeOrderBooks", keys, true).Return(sub, []domain.OrderBookUpdate{}, nil)
+	controller := NewOrderBookController(service)
+
+	conn := dialOrderBookStream(t, controller, "book=binance:SOL_USDT&conflate=true")
+
+	var msg orderBookStreamMessage
+	err := conn.ReadJSON(&msg)
+	require.NoError(t, err)
+	require.Equal(t, "update", msg.Type)
+	require.Equal(t, []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1")}}, msg.Bids)
+}
+
+func TestStreamOrderBooksSlowConsumer(t *testing.T) {
+	keys := []domain.OrderBookKey{{ExchangeName: "binance", Pair: "SOL_USDT"}}
+	hub := domain.NewOrderBookHub(1)
+	sub := hub.Subscribe(keys, false)
+	for i := 0; i < 2; i++ {
+		hub.PublishOrderBook("binance", "SOL_USDT", &domain.OrderBook{})
+	}
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SubscribeOrderBooks", keys, false).Return(sub, []domain.OrderBookUpdate{}, nil)
+	controller := NewOrderBookController(service)
+
+	conn := dialOrderBookStream(t, controller, "book=binance:SOL_USDT")
+
+	_, _, err := conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), fmt.Sprintf("error: %v", err))
+}
+
+func TestStreamOrderBooksDeleted(t *testing.T) {
+	keys := []domain.OrderBookKey{{ExchangeName: "binance", Pair: "LUNA_USDT"}}
+	hub := domain.NewOrderBookHub(16)
+	sub := hub.Subscribe(keys, false)
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SubscribeOrderBooks", keys, false).Return(sub, []domain.OrderBookUpdate{{
+		ExchangeName: "binance",
+		Pair:         "LUNA_USDT",
+		OrderBook:    &domain.OrderBook{Bids: []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1")}}, Version: 5},
+	}}, nil)
+	controller := NewOrderBookController(service)
+
+	conn := dialOrderBookStream(t, controller, "book=binance:LUNA_USDT")
+
+	var msg orderBookStreamMessage
+	err := conn.ReadJSON(&msg)
+	require.NoError(t, err)
+	require.Equal(t, "snapshot", msg.Type)
+
+	hub.PublishOrderBookDeletion("binance", "LUNA_USDT", 5)
+	err = conn.ReadJSON(&msg)
+	require.NoError(t, err)
+	require.Equal(t, "deleted", msg.Type)
+	require.Equal(t, "LUNA_USDT", msg.Pair)
+	require.Empty(t, msg.Bids)
+	require.Empty(t, msg.Asks)
+}
+
+func TestStreamOrderBooksKeepaliveWhileBusy(t *testing.T) {
+	pongWait, pingPeriod := streamPongWait, streamPingPeriod
+	streamPongWait, streamPingPeriod = 300*time.Millisecond, 100*time.Millisecond
+	t.Cleanup(func() { streamPongWait, streamPingPeriod = pongWait, pingPeriod })
+
+	keys := []domain.OrderBookKey{{ExchangeName: "binance", Pair: "SOL_USDT"}}
+	hub := domain.NewOrderBookHub(16)
+	sub := hub.Subscribe(keys, false)
+
+	service := mocks.NewOrderBookService(t)
+	service.On("SubscribeOrderBooks", keys, false).Return(sub, []domain.OrderBookUpdate{}, nil)
+	controller := NewOrderBookController(service)
+
+	conn := dialOrderBookStream(t, controller, "book=binance:SOL_USDT")
+
+	// updates arrive more often than pings are due for longer than the pong wait,
+	// the client answers pings while reading
+	for i := int64(1); time.Duration(i)*20*time.Millisecond < 4*streamPongWait; i++ {
+		hub.PublishOrderBook("binance", "SOL_USDT", &domain.OrderBook{
+			Bids:    []domain.DepthOrder{{Price: decimal.RequireFromString("0.53"), BaseQty: decimal.RequireFromString("1")}},
+			Version: i,
+		})
+		var msg orderBookStreamMessage
+		err := conn.ReadJSON(&msg)
+		require.NoError(t, err, "update %d", i)
+		require.Equal(t, "update", msg.Type)
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestStreamOrderBooksWrongQuery(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "no books", query: ""},
+		{name: "no exchange", query: "book=SOL_USDT"},
+		{name: "empty pair", query: "book=binance:"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := mocks.NewOrderBookService(t)
+			controller := NewOrderBookController(service)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v2/order-books/stream?"+tc.query, nil)
+
+			w := httptest.NewRecorder()
+			router := gin.Default()
+			controller.RegisterRoutes(router)
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func dialOrderBookStream(t *testing.T, controller *OrderBookController, query string) *websocket.Conn {
+	router := gin.Default()
+	controller.RegisterRoutes(router)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v2/order-books/stream?" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
@@ -0,0 +1,107 @@
+package orderbookcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type saveOrderBookRequestURI struct {
+	ExchangeName string `uri:"exchange" binding:"required"`
+	Pair         string `uri:"pair" binding:"required"`
+}
+
+type saveOrderBookRequestBody struct {
+	Bids []domain.DepthOrder `json:"bids" binding:"required"`
+	Asks []domain.DepthOrder `json:"asks" binding:"required"`
+	// Sequence is the sequence number of the snapshot, the next delta must carry Sequence+1.
+	Sequence *int64 `json:"sequence"`
+	// ExchangeTime is the time the exchange reported for the snapshot.
+	ExchangeTime *time.Time `json:"exchangeTime"`
+	// Checksum is the CRC32 checksum of the top levels published by the exchange, signed or unsigned.
+	Checksum *int64 `json:"checksum"`
+	// ChecksumAlgorithm is the algorithm of Checksum: okx or kraken, the exchange name is used if empty.
+	ChecksumAlgorithm string `json:"checksumAlgorithm"`
+}
+
+// saveOrderBook godoc
+// @Summary Save Order Book
+// @Description Saves an order book for a specific exchange and pair, the exchange must be registered and enabled. Bids and asks are passed separately and may have different lengths. Levels are validated according to the validation mode of the exchange. An optional checksum published by the exchange is verified against the received levels, with the algorithm of the exchange unless another one is given. Saving a snapshot clears the resync required state set by rejected deltas and the stale flag. With If-Match the book is saved only if the stored book has one of the given ETags, * matches any stored book.
+// @Tags OrderBook
+// @Accept json
+// @Param exchange path string true "Exchange name"
+// @Param pair path string true "Currency Pair"
+// @Param If-Match header string false "ETags the stored book must have"
+// @Param orderBook body saveOrderBookRequestBody true "Order Book data"
+// @Success 200
+// @Header 200 {string} ETag "Version of the saved book"
+// @Failure 400 {object} httputils.HTTPError "Invalid request body"
+// @Failure 403 {object} httputils.HTTPError "Exchange is disabled"
+// @Failure 404 {object} httputils.HTTPError "Exchange is not registered"
+// @Failure 412 {object} httputils.HTTPError "Stored Order Book does not match If-Match"
+// @Failure 422 {object} httputils.HTTPValidationError "Order Book violates invariants or does not match the checksum"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /exchanges/{exchange}/pairs/{pair}/order-book [put]
+func (c *OrderBookController) saveOrderBook(ctx *gin.Context) {
+	var reqURI saveOrderBookRequestURI
+	err := ctx.BindUri(&reqURI)
+	if err != nil {
+		httputils.BindURIError(ctx, err)
+		return
+	}
+	var reqBody saveOrderBookRequestBody
+	err = ctx.BindJSON(&reqBody)
+	if err != nil {
+		httputils.BindJSONBodyError(ctx, err)
+		return
+	}
+
+	orderBook := &domain.OrderBook{
+		Bids:         reqBody.Bids,
+		Asks:         reqBody.Asks,
+		Sequence:     reqBody.Sequence,
+		ExchangeTime: reqBody.ExchangeTime,
+		Checksum:     orderBookChecksum(reqBody.Checksum, reqBody.ChecksumAlgorithm),
+	}
+	if ifMatch := ctx.GetHeader("If-Match"); ifMatch != "" {
+		versions, all := parseETagVersions(ifMatch)
+		if all {
+			versions = nil
+		} else if len(versions) == 0 {
+			httputils.PreconditionFailedError(ctx, domain.OrderBookVersionMismatch{Message: "order book version does not match"})
+			return
+		}
+		err = c.orderBookService.SaveOrderBookSidesIfMatch(reqURI.ExchangeName, reqURI.Pair, orderBook, versions)
+	} else {
+		err = c.orderBookService.SaveOrderBookSides(reqURI.ExchangeName, reqURI.Pair, orderBook)
+	}
+	switch err := err.(type) {
+	case nil:
+	case domain.ExchangeNotFound:
+		httputils.NotFoundError(ctx, err)
+		return
+	case domain.ExchangeDisabled:
+		httputils.ForbiddenError(ctx, err)
+		return
+	case domain.OrderBookInvalid:
+		httputils.OrderBookInvalidError(ctx, err)
+		return
+	case domain.OrderBookChecksumMismatch, domain.ChecksumAlgorithmUnknown:
+		httputils.UnprocessableEntityError(ctx, err)
+		return
+	case domain.OrderBookVersionMismatch:
+		httputils.PreconditionFailedError(ctx, err)
+		return
+	default:
+		httputils.InternalError(ctx)
+		return
+	}
+
+	if orderBook.Version != 0 {
+		ctx.Header("ETag", orderBookETag(orderBook.Version))
+	}
+	ctx.Status(http.StatusOK)
+}
@@ -0,0 +1,100 @@
+package orderbookcontroller
+
+import (
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type saveOrderBooksRequestQuery struct {
+	Strict bool `form:"strict"`
+}
+
+type saveOrderBooksRequestBody struct {
+	OrderBooks []saveOrderBooksRequestItem `json:"orderBooks" binding:"required,min=1,dive"`
+}
+
+type saveOrderBooksRequestItem struct {
+	ExchangeName string              `json:"exchange" binding:"required"`
+	Pair         string              `json:"pair" binding:"required"`
+	Bids         []domain.DepthOrder `json:"bids" binding:"required"`
+	Asks         []domain.DepthOrder `json:"asks" binding:"required"`
+	Sequence     *int64              `json:"sequence"`
+	ExchangeTime *time.Time          `json:"exchangeTime"`
+	// Checksum is the CRC32 checksum of the top levels published by the exchange, signed or unsigned.
+	Checksum *int64 `json:"checksum"`
+	// ChecksumAlgorithm is the algorithm of Checksum: okx or kraken, the exchange name is used if empty.
+	ChecksumAlgorithm string `json:"checksumAlgorithm"`
+}
+
+type saveOrderBooksResponse struct {
+	// Saved is the number of saved order books.
+	Saved    int                            `json:"saved"`
+	Failures []domain.OrderBookBatchFailure `json:"failures"`
+}
+
+// saveOrderBooks godoc
+// @Summary Save Order Books
+// @Description Saves many order books in one transaction. Every book is validated according to the validation mode of its exchange, books of exchanges that are not registered or disabled, invalid books and books that do not match their checksum are reported with their index in the batch while the valid ones are saved. In strict mode any rejected book rejects the whole batch with 422.
+// @Tags OrderBook
+// @Accept json
+// @Produce json
+// @Param strict query bool false "Reject the whole batch if any book is invalid"
+// @Param orderBooks body saveOrderBooksRequestBody true "Order Books"
+// @Success 200 {object} saveOrderBooksResponse "Number of saved Order Books and rejected Order Books"
+// @Failure 400 {object} httputils.HTTPError "Invalid request body"
+// @Failure 422 {object} saveOrderBooksResponse "Batch rejected in strict mode"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /order-books [put]
+func (c *OrderBookController) saveOrderBooks(ctx *gin.Context) {
+	var reqQuery saveOrderBooksRequestQuery
+	err := ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+	var reqBody saveOrderBooksRequestBody
+	err = ctx.BindJSON(&reqBody)
+	if err != nil {
+		httputils.BindJSONBodyError(ctx, err)
+		return
+	}
+
+	items := make([]domain.OrderBookBatchItem, 0, len(reqBody.OrderBooks))
+	for _, orderBook := range reqBody.OrderBooks {
+		items = append(items, domain.OrderBookBatchItem{
+			ExchangeName: orderBook.ExchangeName,
+			Pair:         orderBook.Pair,
+			OrderBook: &domain.OrderBook{
+				Bids:         orderBook.Bids,
+				Asks:         orderBook.Asks,
+				Sequence:     orderBook.Sequence,
+				ExchangeTime: orderBook.ExchangeTime,
+				Checksum:     orderBookChecksum(orderBook.Checksum, orderBook.ChecksumAlgorithm),
+			},
+		})
+	}
+
+	failures, err := c.orderBookService.SaveOrderBooks(items, reqQuery.Strict)
+	if err != nil {
+		httputils.InternalError(ctx)
+		return
+	}
+	if failures == nil {
+		failures = []domain.OrderBookBatchFailure{}
+	}
+
+	if reqQuery.Strict && len(failures) > 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, saveOrderBooksResponse{
+			Failures: failures,
+		})
+		return
+	}
+	ctx.JSON(http.StatusOK, saveOrderBooksResponse{
+		Saved:    len(items) - len(failures),
+		Failures: failures,
+	})
+}
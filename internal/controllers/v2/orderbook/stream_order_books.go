@@ -0,0 +1,209 @@
+package orderbookcontroller
+
+import (
+	"context"
+	"fmt"
+	"market-info-storage/internal/controllers/httputils"
+	"market-info-storage/internal/domain"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Stream timings are variables so tests can shorten them.
+var (
+	streamWriteWait  = 10 * time.Second
+	streamPongWait   = 60 * time.Second
+	streamPingPeriod = streamPongWait * 9 / 10
+)
+
+// Stream consumers are services rather than browsers, so connections from any origin are accepted.
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type streamOrderBooksRequestQuery struct {
+	Books    []string `form:"book" binding:"required,min=1,dive,required"`
+	Conflate bool     `form:"conflate"`
+	Depth    int      `form:"depth" binding:"min=0"`
+}
+
+type orderBookStreamMessage struct {
+	// Type is snapshot for the state sent on subscription, update for later states and
+	// deleted with no levels when the book was deleted.
+	Type         string              `json:"type"`
+	ExchangeName string              `json:"exchange"`
+	Pair         string              `json:"pair"`
+	Bids         []domain.DepthOrder `json:"bids"`
+	Asks         []domain.DepthOrder `json:"asks"`
+	Sequence     *int64              `json:"sequence,omitempty"`
+	UpdatedAt    time.Time           `json:"updatedAt"`
+	ExchangeTime *time.Time          `json:"exchangeTime,omitempty"`
+}
+
+// streamOrderBooks godoc
+// @Summary Stream Order Books
+// @Description Upgrades the connection to WebSocket and streams the chosen order books. The current state of every existing book is sent as a snapshot message right away, every later save or delta is sent as an update message with the whole book and a deletion of the book as a deleted message without levels. Without conflation a client that falls behind by more than the configured number of updates is disconnected with close code 1008, with conflation only the latest state of every book is kept for it.
+// @Tags OrderBook
+// @Param book query []string true "Order Books as exchange:pair" collectionFormat(multi)
+// @Param conflate query bool false "Send only the latest state of every book to a slow client"
+// @Param depth query int false "Maximum number of levels per side, 0 for all levels"
+// @Success 101
+// @Failure 400 {object} httputils.HTTPError "Invalid request"
+// @Failure 500 {object} httputils.HTTPError "Internal server error"
+// @Router /order-books/stream [get]
+func (c *OrderBookController) streamOrderBooks(ctx *gin.Context) {
+	var reqQuery streamOrderBooksRequestQuery
+	err := ctx.BindQuery(&reqQuery)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+	keys, err := parseOrderBookKeys(reqQuery.Books)
+	if err != nil {
+		httputils.BindQueryError(ctx, err)
+		return
+	}
+
+	sub, snapshots, err := c.orderBookService.SubscribeOrderBooks(keys, reqQuery.Conflate)
+	if err != nil {
+		httputils.InternalError(ctx)
+		return
+	}
+	defer sub.Close()
+
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// the upgrader has already responded with an error
+		return
+	}
+	defer conn.Close()
+
+	streamCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+	go readStream(conn, cancel)
+	go pingStream(streamCtx, conn, cancel)
+
+	opts := domain.OrderBookReadOptions{Depth: reqQuery.Depth}
+	versions := make(map[domain.OrderBookKey]int64, len(snapshots))
+	for _, snapshot := range snapshots {
+		versions[domain.OrderBookKey{ExchangeName: snapshot.ExchangeName, Pair: snapshot.Pair}] = snapshot.OrderBook.Version
+		err = writeStreamMessage(conn, "snapshot", snapshot, opts)
+		if err != nil {
+			return
+		}
+	}
+
+	for {
+		updates, err := sub.Next(streamCtx)
+		switch err.(type) {
+		case nil:
+		case domain.OrderBookSubscriptionOverflow:
+			closeStream(conn, websocket.ClosePolicyViolation, err.Error()+", reconnect with conflate=true")
+			return
+		default:
+			return
+		}
+
+		for _, update := range updates {
+			key := domain.OrderBookKey{ExchangeName: update.ExchangeName, Pair: update.Pair}
+			messageType := "update"
+			if update.Deleted {
+				// the deletion carries the version of the deleted book
+				if update.OrderBook.Version < versions[key] {
+					continue
+				}
+				messageType = "deleted"
+			} else if update.OrderBook.Version != 0 && update.OrderBook.Version <= versions[key] {
+				continue
+			}
+			versions[key] = update.OrderBook.Version
+			err = writeStreamMessage(conn, messageType, update, opts)
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseOrderBookKeys parses books passed as exchange:pair.
+func parseOrderBookKeys(books []string) ([]domain.OrderBookKey, error) {
+	keys := make([]domain.OrderBookKey, 0, len(books))
+	for _, book := range books {
+		exchangeName, pair, ok := strings.Cut(book, ":")
+		if !ok || exchangeName == "" || pair == "" {
+			return nil, fmt.Errorf("book %q is not in exchange:pair format", book)
+		}
+		keys = append(keys, domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair})
+	}
+	return keys, nil
+}
+
+// readStream discards client messages and handles control frames, cancel is called once the connection is closed.
+func readStream(conn *websocket.Conn, cancel context.CancelFunc) {
+	defer cancel()
+	conn.SetReadLimit(512)
+	conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	})
+	for {
+		_, _, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// pingStream pings the client every streamPingPeriod whether or not updates are sent,
+// the read deadline is extended only by pongs. WriteControl may be called concurrently
+// with the writes of updates.
+func pingStream(ctx context.Context, conn *websocket.Conn, cancel context.CancelFunc) {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait))
+			if err != nil {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+func writeStreamMessage(conn *websocket.Conn, messageType string, update domain.OrderBookUpdate, opts domain.OrderBookReadOptions) error {
+	orderBook := *update.OrderBook
+	orderBook.ApplyReadOptions(opts)
+	msg := orderBookStreamMessage{
+		Type:         messageType,
+		ExchangeName: update.ExchangeName,
+		Pair:         update.Pair,
+		Bids:         orderBook.Bids,
+		Asks:         orderBook.Asks,
+		Sequence:     orderBook.Sequence,
+		UpdatedAt:    orderBook.UpdatedAt,
+		ExchangeTime: orderBook.ExchangeTime,
+	}
+	if msg.Bids == nil {
+		msg.Bids = []domain.DepthOrder{}
+	}
+	if msg.Asks == nil {
+		msg.Asks = []domain.DepthOrder{}
+	}
+
+	conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+	return conn.WriteJSON(msg)
+}
+
+func closeStream(conn *websocket.Conn, code int, text string) {
+	conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, text))
+}
@@ -3,18 +3,14 @@ package postgres
 import (
 	"fmt"
 	"market-info-storage/internal/config"
+	"time"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 func NewClient(cfg config.DBConfig) (*sqlx.DB, error) {
-	connInfo := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.DBName, cfg.SSLMode,
-	)
-
-	client, err := sqlx.Connect("postgres", connInfo)
+	client, err := sqlx.Connect("postgres", connInfo(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -26,3 +22,16 @@ func NewClient(cfg config.DBConfig) (*sqlx.DB, error) {
 
 	return client, nil
 }
+
+// NewListener creates a LISTEN connection that reconnects after failures
+// with an interval growing from minReconnectInterval to maxReconnectInterval.
+func NewListener(cfg config.DBConfig, minReconnectInterval, maxReconnectInterval time.Duration, eventCallback pq.EventCallbackType) *pq.Listener {
+	return pq.NewListener(connInfo(cfg), minReconnectInterval, maxReconnectInterval, eventCallback)
+}
+
+func connInfo(cfg config.DBConfig) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.Username, cfg.Password, cfg.DBName, cfg.SSLMode,
+	)
+}
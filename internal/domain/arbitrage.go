@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ArbitrageOpportunity is buying a pair on one exchange and selling it on another
+// at a profit after taker fees on both sides.
+type ArbitrageOpportunity struct {
+	Pair         string `json:"pair"`
+	BuyExchange  string `json:"buyExchange"`
+	SellExchange string `json:"sellExchange"`
+	// BestAsk on the buy exchange and BestBid on the sell exchange.
+	BestAsk decimal.Decimal `json:"bestAsk" swaggertype:"string"`
+	BestBid decimal.Decimal `json:"bestBid" swaggertype:"string"`
+	// BaseQty is the size executable at a profit by walking both books, summed exactly from level quantities.
+	BaseQty decimal.Decimal `json:"baseQty" swaggertype:"string"`
+	// BuyQuoteQty is the cost including fees, SellQuoteQty is the proceeds net of fees.
+	// Being fee adjusted estimates they are float64 as is the profit.
+	BuyQuoteQty  float64   `json:"buyQuoteQty"`
+	SellQuoteQty float64   `json:"sellQuoteQty"`
+	Profit       float64   `json:"profit"`
+	ProfitBps    float64   `json:"profitBps"`
+	DetectedAt   time.Time `json:"detectedAt"`
+}
+
+// TakerFees are taker fee rates, for example 0.001 for 0.1%.
+type TakerFees struct {
+	defaultFee   float64
+	exchangeFees map[string]float64
+	// exchanges override exchangeFees with the taker fee of registered exchanges, nil if there is no registry
+	exchanges ExchangeLookup
+}
+
+func NewTakerFees(defaultFee float64, exchangeFees map[string]float64, exchanges ExchangeLookup) *TakerFees {
+	return &TakerFees{
+		defaultFee:   defaultFee,
+		exchangeFees: exchangeFees,
+		exchanges:    exchanges,
+	}
+}
+
+func (f *TakerFees) TakerFee(exchangeName string) float64 {
+	if f.exchanges != nil {
+		if exchange, ok := f.exchanges.LookupExchange(exchangeName); ok && exchange.TakerFee != nil {
+			return *exchange.TakerFee
+		}
+	}
+	if fee, ok := f.exchangeFees[exchangeName]; ok {
+		return fee
+	}
+	return f.defaultFee
+}
+
+// FindArbitrageOpportunities checks every ordered pair of exchanges holding a book
+// of the pair, sorted by profit in descending order.
+func FindArbitrageOpportunities(pair string, orderBooks map[string]*OrderBook, fees *TakerFees, detectedAt time.Time) []ArbitrageOpportunity {
+	var opportunities []ArbitrageOpportunity
+	for buyExchange, buyOrderBook := range orderBooks {
+		for sellExchange, sellOrderBook := range orderBooks {
+			if buyExchange == sellExchange {
+				continue
+			}
+			opportunity, ok := walkArbitrage(buyOrderBook.Asks, sellOrderBook.Bids,
+				fees.TakerFee(buyExchange), fees.TakerFee(sellExchange))
+			if !ok {
+				continue
+			}
+			opportunity.Pair = pair
+			opportunity.BuyExchange = buyExchange
+			opportunity.SellExchange = sellExchange
+			opportunity.DetectedAt = detectedAt
+			opportunities = append(opportunities, opportunity)
+		}
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool {
+		return opportunities[i].Profit > opportunities[j].Profit
+	})
+	return opportunities
+}
+
+// walkArbitrage matches asks against bids while the bid net of the sell fee exceeds the ask
+// including the buy fee. Quantities are matched exactly, fee adjusted amounts are float64 estimates.
+func walkArbitrage(asks, bids []DepthOrder, buyFee, sellFee float64) (ArbitrageOpportunity, bool) {
+	if len(asks) == 0 || len(bids) == 0 {
+		return ArbitrageOpportunity{}, false
+	}
+	opportunity := ArbitrageOpportunity{
+		BestAsk: asks[0].Price,
+		BestBid: bids[0].Price,
+	}
+
+	i, j := 0, 0
+	askQty, bidQty := asks[0].BaseQty, bids[0].BaseQty
+	for i < len(asks) && j < len(bids) {
+		buyPrice := asks[i].Price.InexactFloat64() * (1 + buyFee)
+		sellPrice := bids[j].Price.InexactFloat64() * (1 - sellFee)
+		if sellPrice <= buyPrice {
+			break
+		}
+
+		qty := decimal.Min(askQty, bidQty)
+		opportunity.BaseQty = opportunity.BaseQty.Add(qty)
+		opportunity.BuyQuoteQty += qty.InexactFloat64() * buyPrice
+		opportunity.SellQuoteQty += qty.InexactFloat64() * sellPrice
+
+		askQty = askQty.Sub(qty)
+		bidQty = bidQty.Sub(qty)
+		if askQty.IsZero() {
+			i++
+			if i < len(asks) {
+				askQty = asks[i].BaseQty
+			}
+		}
+		if bidQty.IsZero() {
+			j++
+			if j < len(bids) {
+				bidQty = bids[j].BaseQty
+			}
+		}
+	}
+	if opportunity.BaseQty.IsZero() {
+		return ArbitrageOpportunity{}, false
+	}
+
+	opportunity.Profit = opportunity.SellQuoteQty - opportunity.BuyQuoteQty
+	opportunity.ProfitBps = opportunity.Profit / opportunity.BuyQuoteQty * bps
+	return opportunity, true
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"log/slog"
+	"market-info-storage/internal/utils/slogutils"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type ArbitrageService struct {
+	orderBookStorage            OrderBookStorage
+	arbitrageOpportunityStorage ArbitrageOpportunityStorage
+	takerFees                   *TakerFees
+	symbols                     *SymbolNormalizer
+	// maxBookAge skips books updated longer ago, 0 disables the check
+	maxBookAge time.Duration
+	// scanDepth is the number of levels per side read from every book, 0 reads all levels
+	scanDepth int
+}
+
+type ArbitrageOpportunityStorage interface {
+	SaveArbitrageOpportunities(opportunities []ArbitrageOpportunity) error
+}
+
+func NewArbitrageService(
+	orderBookStorage OrderBookStorage,
+	arbitrageOpportunityStorage ArbitrageOpportunityStorage,
+	takerFees *TakerFees,
+	symbols *SymbolNormalizer,
+	maxBookAge time.Duration,
+	scanDepth int,
+) *ArbitrageService {
+	return &ArbitrageService{
+		orderBookStorage:            orderBookStorage,
+		arbitrageOpportunityStorage: arbitrageOpportunityStorage,
+		takerFees:                   takerFees,
+		symbols:                     symbols,
+		maxBookAge:                  maxBookAge,
+		scanDepth:                   scanDepth,
+	}
+}
+
+// FindArbitrageOpportunities scans the latest stored order books of pairs on exchangeNames,
+// all pairs or exchanges are scanned if pairs or exchangeNames are empty. Stale books are
+// skipped and only the top levels of the others are walked. Opportunities are sorted
+// by profit in descending order and saved for later review if record is set.
+func (s *ArbitrageService) FindArbitrageOpportunities(pairs, exchangeNames []string, record bool) ([]ArbitrageOpportunity, error) {
+	normalizedPairs := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		normalizedPairs = append(normalizedPairs, s.symbols.NormalizePair("", pair))
+	}
+	orderBooks, err := s.orderBookStorage.GetOrderBooks(normalizedPairs, exchangeNames, OrderBookReadOptions{Depth: s.scanDepth})
+	if err != nil {
+		err = errors.Wrap(err, "get order books")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+
+	detectedAt := time.Now()
+	var opportunities []ArbitrageOpportunity
+	for pair, pairOrderBooks := range orderBooks {
+		pairOrderBooks = s.freshOrderBooks(pairOrderBooks, detectedAt)
+		opportunities = append(opportunities, FindArbitrageOpportunities(pair, pairOrderBooks, s.takerFees, detectedAt)...)
+	}
+	sort.Slice(opportunities, func(i, j int) bool {
+		return opportunities[i].Profit > opportunities[j].Profit
+	})
+
+	if record && len(opportunities) > 0 {
+		err = s.arbitrageOpportunityStorage.SaveArbitrageOpportunities(opportunities)
+		if err != nil {
+			err = errors.Wrap(err, "save arbitrage opportunities")
+			slog.Error("", slogutils.ErrorAttr(err))
+			return nil, err
+		}
+	}
+
+	return opportunities, nil
+}
+
+// freshOrderBooks drops books flagged stale or updated longer than maxBookAge before now,
+// prices of such books no longer reflect the exchange.
+func (s *ArbitrageService) freshOrderBooks(orderBooks map[string]*OrderBook, now time.Time) map[string]*OrderBook {
+	fresh := make(map[string]*OrderBook, len(orderBooks))
+	for exchangeName, orderBook := range orderBooks {
+		if orderBook.Stale || s.maxBookAge > 0 && now.Sub(orderBook.UpdatedAt) > s.maxBookAge {
+			continue
+		}
+		fresh[exchangeName] = orderBook
+	}
+	return fresh
+}
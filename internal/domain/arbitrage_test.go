@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkArbitrage(t *testing.T) {
+	testCases := []struct {
+		name        string
+		asks        []DepthOrder
+		bids        []DepthOrder
+		buyFee      float64
+		sellFee     float64
+		found       bool
+		bestAsk     string
+		bestBid     string
+		baseQty     string
+		opportunity ArbitrageOpportunity
+	}{
+		{
+			name:    "FeeAdjustedWalk",
+			asks:    []DepthOrder{level("100", "1"), level("101", "2")},
+			bids:    []DepthOrder{level("102", "1.5"), level("100.5", "1")},
+			buyFee:  0.001,
+			sellFee: 0.001,
+			found:   true,
+			bestAsk: "100",
+			bestBid: "102",
+			baseQty: "1.5",
+			opportunity: ArbitrageOpportunity{
+				BuyQuoteQty:  100.1 + 0.5*101.101,
+				SellQuoteQty: 1.5 * 101.898,
+				Profit:       1.5*101.898 - (100.1 + 0.5*101.101),
+				ProfitBps:    (1.5*101.898 - (100.1 + 0.5*101.101)) / (100.1 + 0.5*101.101) * bps,
+			},
+		},
+		{
+			name:    "NoFees",
+			asks:    []DepthOrder{level("100", "1")},
+			bids:    []DepthOrder{level("100.15", "2")},
+			found:   true,
+			bestAsk: "100",
+			bestBid: "100.15",
+			baseQty: "1",
+			opportunity: ArbitrageOpportunity{
+				BuyQuoteQty: 100, SellQuoteQty: 100.15, Profit: 0.15, ProfitBps: 15,
+			},
+		},
+		{
+			// float64 would sum the quantities to 0.30000000000000004
+			name:    "ExactQuantities",
+			asks:    []DepthOrder{level("100.10", "0.1"), level("100.10", "0.2")},
+			bids:    []DepthOrder{level("101", "0.3")},
+			found:   true,
+			bestAsk: "100.1",
+			bestBid: "101",
+			baseQty: "0.3",
+			opportunity: ArbitrageOpportunity{
+				BuyQuoteQty: 0.3 * 100.1, SellQuoteQty: 0.3 * 101, Profit: 0.3 * 0.9, ProfitBps: 0.9 / 100.1 * bps,
+			},
+		},
+		{
+			name:    "FeesEraseSpread",
+			asks:    []DepthOrder{level("100", "1")},
+			bids:    []DepthOrder{level("100.15", "2")},
+			buyFee:  0.001,
+			sellFee: 0.001,
+		},
+		{
+			name: "BidBelowAsk",
+			asks: []DepthOrder{level("101", "1")},
+			bids: []DepthOrder{level("100", "1")},
+		},
+		{
+			name: "EqualPrices",
+			asks: []DepthOrder{level("100", "1")},
+			bids: []DepthOrder{level("100", "1")},
+		},
+		{
+			name: "EmptyAsks",
+			bids: []DepthOrder{level("100", "1")},
+		},
+		{
+			name: "EmptyBids",
+			asks: []DepthOrder{level("100", "1")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opportunity, found := walkArbitrage(tc.asks, tc.bids, tc.buyFee, tc.sellFee)
+			require.Equal(t, tc.found, found)
+			if !found {
+				return
+			}
+			require.Equal(t, tc.bestAsk, opportunity.BestAsk.String())
+			require.Equal(t, tc.bestBid, opportunity.BestBid.String())
+			require.Equal(t, tc.baseQty, opportunity.BaseQty.String())
+			require.InDelta(t, tc.opportunity.BuyQuoteQty, opportunity.BuyQuoteQty, 1e-9)
+			require.InDelta(t, tc.opportunity.SellQuoteQty, opportunity.SellQuoteQty, 1e-9)
+			require.InDelta(t, tc.opportunity.Profit, opportunity.Profit, 1e-9)
+			require.InDelta(t, tc.opportunity.ProfitBps, opportunity.ProfitBps, 1e-6)
+		})
+	}
+}
+
+func TestFindArbitrageOpportunities(t *testing.T) {
+	orderBooks := map[string]*OrderBook{
+		"binance": {Bids: []DepthOrder{level("99", "1")}, Asks: []DepthOrder{level("100", "1")}},
+		"bybit":   {Bids: []DepthOrder{level("102", "1")}, Asks: []DepthOrder{level("103", "1")}},
+		"okx":     {Bids: []DepthOrder{level("101", "1")}, Asks: []DepthOrder{level("105", "1")}},
+	}
+	testCases := []struct {
+		name   string
+		fees   *TakerFees
+		trades [][2]string
+	}{
+		{
+			name:   "SortedByProfit",
+			fees:   NewTakerFees(0, nil, nil),
+			trades: [][2]string{{"binance", "bybit"}, {"binance", "okx"}},
+		},
+		{
+			name:   "ExchangeFee",
+			fees:   NewTakerFees(0, map[string]float64{"bybit": 0.02}, nil),
+			trades: [][2]string{{"binance", "okx"}},
+		},
+		{
+			name:   "RegisteredExchangeFee",
+			fees:   NewTakerFees(0, nil, fakeExchangeLookup{"okx": {Name: "okx", TakerFee: floatPtr(0.01)}}),
+			trades: [][2]string{{"binance", "bybit"}},
+		},
+		{
+			name:   "DefaultFee",
+			fees:   NewTakerFees(0.01, nil, nil),
+			trades: [][2]string{},
+		},
+	}
+
+	detectedAt := time.Now()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opportunities := FindArbitrageOpportunities("BTC_USDT", orderBooks, tc.fees, detectedAt)
+			trades := make([][2]string, 0, len(opportunities))
+			for _, opportunity := range opportunities {
+				require.Equal(t, "BTC_USDT", opportunity.Pair)
+				require.Equal(t, detectedAt, opportunity.DetectedAt)
+				trades = append(trades, [2]string{opportunity.BuyExchange, opportunity.SellExchange})
+			}
+			require.Equal(t, tc.trades, trades)
+		})
+	}
+}
+
+func TestFreshOrderBooks(t *testing.T) {
+	now := time.Now()
+	orderBooks := map[string]*OrderBook{
+		"binance": {UpdatedAt: now.Add(-time.Second)},
+		"bybit":   {UpdatedAt: now.Add(-time.Minute)},
+		"okx":     {UpdatedAt: now, Stale: true},
+	}
+	testCases := []struct {
+		name       string
+		maxBookAge time.Duration
+		exchanges  []string
+	}{
+		{name: "MaxBookAge", maxBookAge: 10 * time.Second, exchanges: []string{"binance"}},
+		{name: "AgeCheckDisabled", maxBookAge: 0, exchanges: []string{"binance", "bybit"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewArbitrageService(nil, nil, NewTakerFees(0, nil, nil), nil, tc.maxBookAge, 0)
+			fresh := service.freshOrderBooks(orderBooks, now)
+			exchanges := make([]string, 0, len(fresh))
+			for exchangeName := range fresh {
+				exchanges = append(exchanges, exchangeName)
+			}
+			require.ElementsMatch(t, tc.exchanges, exchanges)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+type ConsolidatedDepthOrder struct {
+	Price   decimal.Decimal `json:"price" swaggertype:"string"`
+	BaseQty decimal.Decimal `json:"baseQty" swaggertype:"string"`
+	// Exchanges holds the quantity each exchange contributes to the level.
+	Exchanges map[string]decimal.Decimal `json:"exchanges" swaggertype:"object,string"`
+}
+
+// ConsolidatedOrderBook merges order books of the same pair from several exchanges.
+type ConsolidatedOrderBook struct {
+	Bids []ConsolidatedDepthOrder
+	Asks []ConsolidatedDepthOrder
+}
+
+// ConsolidateOrderBooks merges order books by exchange name into one book. Side and
+// grouping options are applied to every book before merging, depth is applied to the result.
+func ConsolidateOrderBooks(orderBooks map[string]*OrderBook, opts OrderBookReadOptions) *ConsolidatedOrderBook {
+	bidsByExchange := make(map[string][]DepthOrder, len(orderBooks))
+	asksByExchange := make(map[string][]DepthOrder, len(orderBooks))
+	for exchangeName, orderBook := range orderBooks {
+		orderBook.ApplyReadOptions(OrderBookReadOptions{Side: opts.Side, Group: opts.Group})
+		bidsByExchange[exchangeName] = orderBook.Bids
+		asksByExchange[exchangeName] = orderBook.Asks
+	}
+
+	consolidated := &ConsolidatedOrderBook{
+		Bids: consolidateDepthOrders(SideBid, bidsByExchange),
+		Asks: consolidateDepthOrders(SideAsk, asksByExchange),
+	}
+	if opts.Depth > 0 {
+		consolidated.Bids = consolidated.Bids[:min(opts.Depth, len(consolidated.Bids))]
+		consolidated.Asks = consolidated.Asks[:min(opts.Depth, len(consolidated.Asks))]
+	}
+
+	return consolidated
+}
+
+func consolidateDepthOrders(side Side, depthOrdersByExchange map[string][]DepthOrder) []ConsolidatedDepthOrder {
+	// levels are keyed by the normalized price as 0.5 and 0.50 are the same level
+	levelsByPrice := make(map[string]*ConsolidatedDepthOrder)
+	for exchangeName, depthOrders := range depthOrdersByExchange {
+		for _, depthOrder := range depthOrders {
+			price := depthOrder.Price.String()
+			level, ok := levelsByPrice[price]
+			if !ok {
+				level = &ConsolidatedDepthOrder{
+					Price:     depthOrder.Price,
+					Exchanges: make(map[string]decimal.Decimal, 1),
+				}
+				levelsByPrice[price] = level
+			}
+			level.BaseQty = level.BaseQty.Add(depthOrder.BaseQty)
+			level.Exchanges[exchangeName] = level.Exchanges[exchangeName].Add(depthOrder.BaseQty)
+		}
+	}
+
+	consolidated := make([]ConsolidatedDepthOrder, 0, len(levelsByPrice))
+	for _, level := range levelsByPrice {
+		consolidated = append(consolidated, *level)
+	}
+	sort.Slice(consolidated, func(i, j int) bool {
+		if side == SideBid {
+			return consolidated[i].Price.GreaterThan(consolidated[j].Price)
+		}
+		return consolidated[i].Price.LessThan(consolidated[j].Price)
+	})
+
+	return consolidated
+}
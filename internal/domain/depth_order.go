@@ -1,6 +1,10 @@
 package domain
 
+import "github.com/shopspring/decimal"
+
+// DepthOrder is a price level. Price and BaseQty are exact decimals,
+// in JSON they are emitted as strings and accepted as strings or numbers.
 type DepthOrder struct {
-	Price   float64 `json:"price"`
-	BaseQty float64 `json:"baseQty"`
+	Price   decimal.Decimal `json:"price" swaggertype:"string"`
+	BaseQty decimal.Decimal `json:"baseQty" swaggertype:"string"`
 }
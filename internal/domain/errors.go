@@ -1,5 +1,7 @@
 package domain
 
+import "fmt"
+
 type OrderBookNotFound struct {
 	Message string
 }
@@ -7,3 +9,107 @@ type OrderBookNotFound struct {
 func (err OrderBookNotFound) Error() string {
 	return err.Message
 }
+
+type OrderBookInvalid struct {
+	Violations []LevelViolation
+}
+
+func (err OrderBookInvalid) Error() string {
+	return fmt.Sprintf("order book has %d violations", len(err.Violations))
+}
+
+type OrderBookResyncRequired struct {
+	Message string
+}
+
+func (err OrderBookResyncRequired) Error() string {
+	return err.Message
+}
+
+type OrderBookStale struct {
+	Message string
+}
+
+func (err OrderBookStale) Error() string {
+	return err.Message
+}
+
+type ExchangeNotFound struct {
+	Message string
+}
+
+func (err ExchangeNotFound) Error() string {
+	return err.Message
+}
+
+type OrderBookSubscriptionOverflow struct {
+	Message string
+}
+
+func (err OrderBookSubscriptionOverflow) Error() string {
+	return err.Message
+}
+
+type OrderBookVersionMismatch struct {
+	Message string
+}
+
+func (err OrderBookVersionMismatch) Error() string {
+	return err.Message
+}
+
+type InstrumentNotFound struct {
+	Message string
+}
+
+func (err InstrumentNotFound) Error() string {
+	return err.Message
+}
+
+type InstrumentInvalid struct {
+	Message string
+}
+
+func (err InstrumentInvalid) Error() string {
+	return err.Message
+}
+
+type HistoryOrderInvalid struct {
+	Message string
+}
+
+func (err HistoryOrderInvalid) Error() string {
+	return err.Message
+}
+
+type ExchangeDisabled struct {
+	Message string
+}
+
+func (err ExchangeDisabled) Error() string {
+	return err.Message
+}
+
+type ExchangeInvalid struct {
+	Message string
+}
+
+func (err ExchangeInvalid) Error() string {
+	return err.Message
+}
+
+type OrderBookChecksumMismatch struct {
+	Message string
+}
+
+func (err OrderBookChecksumMismatch) Error() string {
+	return err.Message
+}
+
+type ChecksumAlgorithmUnknown struct {
+	Message string
+}
+
+func (err ChecksumAlgorithmUnknown) Error() string {
+	return err.Message
+}
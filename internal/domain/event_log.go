@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type EventType string
+
+const (
+	EventTypeOrderBook        EventType = "order-book"
+	EventTypeOrderBookDeleted EventType = "order-book-deleted"
+	EventTypeHistoryOrder     EventType = "history-order"
+)
+
+// Event is an order book update or deletion or a saved history order. Exactly one of
+// OrderBook and HistoryOrder is set according to Type.
+type Event struct {
+	// ID increases by one with every event starting from 1.
+	ID           uint64
+	Type         EventType
+	OrderBook    *OrderBookUpdate
+	HistoryOrder *HistoryOrder
+}
+
+// EventLog keeps the latest events in a bounded ring buffer so that
+// readers can resume after the last event they received.
+type EventLog struct {
+	mu      sync.Mutex
+	events  []Event
+	lastID  uint64
+	changed chan struct{}
+}
+
+// NewEventLog creates a log keeping the latest size events, size must be positive.
+func NewEventLog(size int) (*EventLog, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("event log size must be positive, got %d", size)
+	}
+	return &EventLog{
+		events:  make([]Event, size),
+		changed: make(chan struct{}),
+	}, nil
+}
+
+func (l *EventLog) PublishOrderBookUpdate(update OrderBookUpdate) {
+	if update.Deleted {
+		l.append(Event{Type: EventTypeOrderBookDeleted, OrderBook: &update})
+		return
+	}
+	l.append(Event{Type: EventTypeOrderBook, OrderBook: &update})
+}
+
+func (l *EventLog) PublishHistoryOrder(order *HistoryOrder) {
+	l.append(Event{Type: EventTypeHistoryOrder, HistoryOrder: order})
+}
+
+func (l *EventLog) append(event Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastID++
+	event.ID = l.lastID
+	l.events[l.lastID%uint64(len(l.events))] = event
+
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+func (l *EventLog) LastEventID() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastID
+}
+
+// WaitEvents returns events after lastEventID and waits for one if there are none yet.
+// ok is false if some of the events are no longer in the buffer or lastEventID is unknown.
+func (l *EventLog) WaitEvents(ctx context.Context, lastEventID uint64) (events []Event, ok bool, err error) {
+	for {
+		l.mu.Lock()
+		events, ok = l.eventsSince(lastEventID)
+		changed := l.changed
+		l.mu.Unlock()
+		if !ok || len(events) > 0 {
+			return events, ok, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func (l *EventLog) eventsSince(lastEventID uint64) ([]Event, bool) {
+	if lastEventID > l.lastID {
+		return nil, false
+	}
+	size := uint64(len(l.events))
+	if l.lastID-lastEventID > size {
+		return nil, false
+	}
+
+	events := make([]Event, 0, l.lastID-lastEventID)
+	for id := lastEventID + 1; id <= l.lastID; id++ {
+		events = append(events, l.events[id%size])
+	}
+	return events, true
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventLogRejectsSize(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		_, err := NewEventLog(size)
+		require.Error(t, err, size)
+	}
+}
+
+func TestEventLogEventsSince(t *testing.T) {
+	testCases := []struct {
+		name        string
+		size        int
+		published   int
+		lastEventID uint64
+		ids         []uint64
+		ok          bool
+	}{
+		{name: "Empty", size: 3, published: 0, lastEventID: 0, ids: []uint64{}, ok: true},
+		{name: "FromStart", size: 3, published: 2, lastEventID: 0, ids: []uint64{1, 2}, ok: true},
+		{name: "FullBuffer", size: 3, published: 3, lastEventID: 0, ids: []uint64{1, 2, 3}, ok: true},
+		{name: "Wraparound", size: 3, published: 7, lastEventID: 4, ids: []uint64{5, 6, 7}, ok: true},
+		{name: "WraparoundPartial", size: 3, published: 7, lastEventID: 5, ids: []uint64{6, 7}, ok: true},
+		{name: "UpToDate", size: 3, published: 7, lastEventID: 7, ids: []uint64{}, ok: true},
+		{name: "SingleSlot", size: 1, published: 5, lastEventID: 4, ids: []uint64{5}, ok: true},
+		// resuming after an evicted event requires a snapshot
+		{name: "EvictedFromStart", size: 3, published: 4, lastEventID: 0, ok: false},
+		{name: "Evicted", size: 3, published: 7, lastEventID: 3, ok: false},
+		{name: "UnknownID", size: 3, published: 2, lastEventID: 3, ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eventLog, err := NewEventLog(tc.size)
+			require.NoError(t, err)
+			for i := 0; i < tc.published; i++ {
+				eventLog.PublishHistoryOrder(&HistoryOrder{})
+			}
+
+			events, ok := eventLog.eventsSince(tc.lastEventID)
+			require.Equal(t, tc.ok, ok)
+			if !ok {
+				require.Nil(t, events)
+				return
+			}
+			ids := make([]uint64, 0, len(events))
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			require.Equal(t, tc.ids, ids)
+		})
+	}
+}
+
+func TestEventLogWaitEvents(t *testing.T) {
+	eventLog, err := NewEventLog(2)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		eventLog.PublishHistoryOrder(&HistoryOrder{})
+	}
+
+	// a gap is reported at once instead of waiting
+	events, ok, err := eventLog.WaitEvents(context.Background(), 0)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Empty(t, events)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		eventLog.PublishOrderBookUpdate(OrderBookUpdate{ExchangeName: "bybit", Pair: "BTC_USDT", OrderBook: &OrderBook{}})
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	events, ok, err = eventLog.WaitEvents(ctx, 3)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(4), events[0].ID)
+	require.Equal(t, EventTypeOrderBook, events[0].Type)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, ok, err = eventLog.WaitEvents(ctx, 4)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.True(t, ok)
+}
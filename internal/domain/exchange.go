@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Exchange holds registry settings of an exchange. Data of an exchange
+// is only accepted once the exchange is registered and enabled.
+type Exchange struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Enabled     bool   `json:"enabled"`
+	// TakerFee and MakerFee are fee rates, for example 0.001 for 0.1%.
+	// A nil TakerFee falls back to the configured arbitrage taker fee.
+	TakerFee *float64 `json:"takerFee,omitempty"`
+	MakerFee *float64 `json:"makerFee,omitempty"`
+	// ValidationMode overrides the configured order book validation mode unless empty.
+	ValidationMode ValidationMode `json:"validationMode,omitempty" enums:"strict,lenient"`
+	// SnapshotRetentionHours is how long order book snapshots are kept, 0 keeps them forever.
+	// A snapshot keeps the retention it was saved with.
+	SnapshotRetentionHours int       `json:"snapshotRetentionHours"`
+	UpdatedAt              time.Time `json:"updatedAt"`
+}
+
+// ExchangeLookup finds registered exchanges without a query.
+type ExchangeLookup interface {
+	LookupExchange(exchangeName string) (Exchange, bool)
+}
+
+// checkExchangeWritable returns ExchangeNotFound for an exchange that is
+// not registered and ExchangeDisabled for a disabled one.
+func checkExchangeWritable(exchanges ExchangeLookup, exchangeName string) error {
+	exchange, ok := exchanges.LookupExchange(exchangeName)
+	if !ok {
+		return ExchangeNotFound{Message: fmt.Sprintf("exchange %q is not registered", exchangeName)}
+	}
+	if !exchange.Enabled {
+		return ExchangeDisabled{Message: fmt.Sprintf("exchange %q is disabled", exchangeName)}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+	"market-info-storage/internal/utils/slogutils"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ExchangeService manages the exchange registry. Like instruments, exchanges are kept
+// in memory for checking writes without a query and refreshed every refreshInterval.
+type ExchangeService struct {
+	exchangeStorage ExchangeStorage
+	refreshInterval time.Duration
+
+	mu        sync.RWMutex
+	exchanges map[string]Exchange
+}
+
+type ExchangeStorage interface {
+	// SaveExchange inserts or replaces the exchange.
+	SaveExchange(exchange *Exchange) error
+	// GetExchange returns ExchangeNotFound if there is no such exchange.
+	GetExchange(exchangeName string) (*Exchange, error)
+	GetExchanges() ([]Exchange, error)
+	// DeleteExchange returns ExchangeNotFound if there is no exchange to delete.
+	DeleteExchange(exchangeName string) error
+}
+
+func NewExchangeService(exchangeStorage ExchangeStorage, refreshInterval time.Duration) *ExchangeService {
+	return &ExchangeService{
+		exchangeStorage: exchangeStorage,
+		refreshInterval: refreshInterval,
+		exchanges:       make(map[string]Exchange),
+	}
+}
+
+// SaveExchange returns ExchangeInvalid for fees outside of [0, 1), an unknown
+// validation mode or a negative snapshot retention.
+func (s *ExchangeService) SaveExchange(exchange *Exchange) error {
+	if strings.TrimSpace(exchange.Name) == "" {
+		return ExchangeInvalid{Message: "name is empty"}
+	}
+	if exchange.DisplayName == "" {
+		exchange.DisplayName = exchange.Name
+	}
+	switch {
+	case !isFeeRate(exchange.TakerFee):
+		return ExchangeInvalid{Message: "taker fee is not in [0, 1)"}
+	case !isFeeRate(exchange.MakerFee):
+		return ExchangeInvalid{Message: "maker fee is not in [0, 1)"}
+	case exchange.SnapshotRetentionHours < 0:
+		return ExchangeInvalid{Message: "snapshot retention is negative"}
+	}
+	if exchange.ValidationMode != "" {
+		_, err := ParseValidationMode(string(exchange.ValidationMode))
+		if err != nil {
+			return ExchangeInvalid{Message: err.Error()}
+		}
+	}
+	exchange.UpdatedAt = time.Now()
+
+	err := s.exchangeStorage.SaveExchange(exchange)
+	if err != nil {
+		err = errors.Wrap(err, "save exchange")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return err
+	}
+
+	s.mu.Lock()
+	s.exchanges[exchange.Name] = *exchange
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *ExchangeService) GetExchange(exchangeName string) (*Exchange, error) {
+	exchange, err := s.exchangeStorage.GetExchange(exchangeName)
+	switch err.(type) {
+	case nil:
+		return exchange, nil
+	case ExchangeNotFound:
+		return nil, err
+	default:
+		err = errors.Wrap(err, "get exchange")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+}
+
+func (s *ExchangeService) GetExchanges() ([]Exchange, error) {
+	exchanges, err := s.exchangeStorage.GetExchanges()
+	if err != nil {
+		err = errors.Wrap(err, "get exchanges")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+	return exchanges, nil
+}
+
+// DeleteExchange removes the exchange from the registry, its stored data is kept.
+func (s *ExchangeService) DeleteExchange(exchangeName string) error {
+	err := s.exchangeStorage.DeleteExchange(exchangeName)
+	switch err.(type) {
+	case nil, ExchangeNotFound:
+	default:
+		err = errors.Wrap(err, "delete exchange")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.exchanges, exchangeName)
+	s.mu.Unlock()
+	return err
+}
+
+// LookupExchange returns the in-memory copy of the exchange.
+func (s *ExchangeService) LookupExchange(exchangeName string) (Exchange, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	exchange, ok := s.exchanges[exchangeName]
+	return exchange, ok
+}
+
+// Refresh replaces the in-memory copy with the stored exchanges.
+func (s *ExchangeService) Refresh() error {
+	exchanges, err := s.exchangeStorage.GetExchanges()
+	if err != nil {
+		return errors.Wrap(err, "refresh exchanges")
+	}
+
+	byName := make(map[string]Exchange, len(exchanges))
+	for _, exchange := range exchanges {
+		byName[exchange.Name] = exchange
+	}
+	s.mu.Lock()
+	s.exchanges = byName
+	s.mu.Unlock()
+	return nil
+}
+
+// Run refreshes exchanges until ctx is done, it returns at once if refreshInterval
+// is not positive and exchanges are only loaded at startup.
+func (s *ExchangeService) Run(ctx context.Context) {
+	if s.refreshInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.Refresh()
+			if err != nil {
+				slog.Error("", slogutils.ErrorAttr(err))
+			}
+		}
+	}
+}
+
+// isFeeRate accepts an unset fee.
+func isFeeRate(fee *float64) bool {
+	return fee == nil || *fee >= 0 && *fee < 1
+}
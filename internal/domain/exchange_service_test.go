@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExchangeServiceRunDisabled(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		service := NewExchangeService(nil, interval)
+		requireReturns(t, func() { service.Run(context.Background()) })
+	}
+}
@@ -1,19 +1,23 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type HistoryOrder struct {
-	ClientName          string    `json:"clinet"`
-	ExchangeName        string    `json:"exchangeName"`
-	Label               string    `json:"label"`
-	Pair                string    `json:"pair"`
-	Side                string    `json:"side"`
-	Type                string    `json:"type"`
-	BaseQty             float64   `json:"baseQty"`
-	Price               float64   `json:"price"`
-	AlgorithmNamePlaced string    `json:"algorithmNamePlaced"`
-	LowestSellPrc       float64   `json:"lowestSellPrc"`
-	HighestBuyPrc       float64   `json:"highestBuyPrc"`
-	CommissionQuoteQty  float64   `json:"commissionQuoteQty"`
-	TimePlaced          time.Time `json:"timePlaced"`
+	ClientName          string          `json:"clinet"`
+	ExchangeName        string          `json:"exchangeName"`
+	Label               string          `json:"label"`
+	Pair                string          `json:"pair"`
+	Side                string          `json:"side"`
+	Type                string          `json:"type"`
+	BaseQty             decimal.Decimal `json:"baseQty" swaggertype:"string"`
+	Price               decimal.Decimal `json:"price" swaggertype:"string"`
+	AlgorithmNamePlaced string          `json:"algorithmNamePlaced"`
+	LowestSellPrc       decimal.Decimal `json:"lowestSellPrc" swaggertype:"string"`
+	HighestBuyPrc       decimal.Decimal `json:"highestBuyPrc" swaggertype:"string"`
+	CommissionQuoteQty  decimal.Decimal `json:"commissionQuoteQty" swaggertype:"string"`
+	TimePlaced          time.Time       `json:"timePlaced"`
 }
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Instrument holds trading rules of a pair on an exchange.
+type Instrument struct {
+	ExchangeName string `json:"exchange"`
+	Pair         string `json:"pair"`
+	Base         string `json:"base"`
+	Quote        string `json:"quote"`
+	// TickSize is the price step, prices must be its multiples.
+	TickSize decimal.Decimal `json:"tickSize" swaggertype:"string"`
+	// QtyStep is the base quantity step, quantities must be its multiples.
+	QtyStep decimal.Decimal `json:"qtyStep" swaggertype:"string"`
+	// MinNotional is the minimum quote quantity of an order, 0 for no minimum.
+	MinNotional decimal.Decimal `json:"minNotional" swaggertype:"string"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+// FormattedDepthOrder is a level with price and quantity formatted to the instrument's precision.
+type FormattedDepthOrder struct {
+	Price   string `json:"price"`
+	BaseQty string `json:"baseQty"`
+}
+
+func (i *Instrument) IsOnTick(price decimal.Decimal) bool {
+	return price.Mod(i.TickSize).IsZero()
+}
+
+func (i *Instrument) IsOnStep(qty decimal.Decimal) bool {
+	return qty.Mod(i.QtyStep).IsZero()
+}
+
+// PricePlaces returns the number of decimal places of the tick size, 2 for 0.01.
+func (i *Instrument) PricePlaces() int32 {
+	return decimalPlaces(i.TickSize)
+}
+
+// QtyPlaces returns the number of decimal places of the quantity step.
+func (i *Instrument) QtyPlaces() int32 {
+	return decimalPlaces(i.QtyStep)
+}
+
+// FormatDepthOrders formats prices with PricePlaces and quantities with QtyPlaces,
+// keeping trailing zeros, so that 0.5 with a tick size of 0.001 becomes 0.500.
+// Values with more places are never rounded, 0.5412 stays 0.5412.
+func (i *Instrument) FormatDepthOrders(depthOrders []DepthOrder) []FormattedDepthOrder {
+	pricePlaces, qtyPlaces := i.PricePlaces(), i.QtyPlaces()
+	formatted := make([]FormattedDepthOrder, len(depthOrders))
+	for j, depthOrder := range depthOrders {
+		formatted[j] = FormattedDepthOrder{
+			Price:   formatPlaces(depthOrder.Price, pricePlaces),
+			BaseQty: formatPlaces(depthOrder.BaseQty, qtyPlaces),
+		}
+	}
+	return formatted
+}
+
+// formatPlaces pads d with trailing zeros up to places, d with more places is formatted as is.
+func formatPlaces(d decimal.Decimal, places int32) string {
+	if decimalPlaces(d) > places {
+		return d.String()
+	}
+	return d.StringFixed(places)
+}
+
+// decimalPlaces ignores trailing zeros, 0.010 has 2 places.
+func decimalPlaces(d decimal.Decimal) int32 {
+	places := int32(0)
+	for !d.Equal(d.Truncate(places)) {
+		places++
+	}
+	return places
+}
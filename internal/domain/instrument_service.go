@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"market-info-storage/internal/utils/slogutils"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// InstrumentService manages the instrument registry. Instruments are also kept in memory
+// for validating saves without a query, the copy is refreshed every refreshInterval to pick
+// up changes made through other instances.
+type InstrumentService struct {
+	instrumentStorage InstrumentStorage
+	symbols           *SymbolNormalizer
+	exchanges         ExchangeLookup
+	refreshInterval   time.Duration
+
+	mu          sync.RWMutex
+	instruments map[OrderBookKey]Instrument
+}
+
+type InstrumentStorage interface {
+	// SaveInstrument inserts or replaces the instrument.
+	SaveInstrument(instrument *Instrument) error
+	// GetInstrument returns InstrumentNotFound if there is no such instrument.
+	GetInstrument(exchangeName, pair string) (*Instrument, error)
+	// GetInstruments returns instruments of the exchange, of all exchanges if exchangeName is empty.
+	GetInstruments(exchangeName string) ([]Instrument, error)
+	// DeleteInstrument returns InstrumentNotFound if there is no instrument to delete.
+	DeleteInstrument(exchangeName, pair string) error
+}
+
+// InstrumentLookup finds instruments without a query.
+type InstrumentLookup interface {
+	LookupInstrument(exchangeName, pair string) (Instrument, bool)
+}
+
+func NewInstrumentService(
+	instrumentStorage InstrumentStorage,
+	symbols *SymbolNormalizer,
+	exchanges ExchangeLookup,
+	refreshInterval time.Duration,
+) *InstrumentService {
+	return &InstrumentService{
+		instrumentStorage: instrumentStorage,
+		symbols:           symbols,
+		exchanges:         exchanges,
+		refreshInterval:   refreshInterval,
+		instruments:       make(map[OrderBookKey]Instrument),
+	}
+}
+
+// SaveInstrument normalizes the pair and fills base and quote assets from it unless they are set.
+// InstrumentInvalid is returned for a tick size or quantity step that is not positive and
+// ExchangeNotFound for an exchange that is not registered, disabled exchanges are accepted.
+func (s *InstrumentService) SaveInstrument(instrument *Instrument) error {
+	if _, ok := s.exchanges.LookupExchange(instrument.ExchangeName); !ok {
+		return ExchangeNotFound{Message: fmt.Sprintf("exchange %q is not registered", instrument.ExchangeName)}
+	}
+	instrument.Pair = s.symbols.NormalizePair(instrument.ExchangeName, instrument.Pair)
+	if instrument.Base == "" || instrument.Quote == "" {
+		base, quote, err := s.symbols.SplitSymbol(instrument.ExchangeName, instrument.Pair)
+		if err != nil {
+			return InstrumentInvalid{Message: "base and quote assets are required: " + err.Error()}
+		}
+		instrument.Base, instrument.Quote = base, quote
+	}
+	instrument.Base = s.symbols.NormalizeAsset(instrument.ExchangeName, instrument.Base)
+	instrument.Quote = s.symbols.NormalizeAsset(instrument.ExchangeName, instrument.Quote)
+	switch {
+	case !instrument.TickSize.IsPositive():
+		return InstrumentInvalid{Message: "tick size is not positive"}
+	case !instrument.QtyStep.IsPositive():
+		return InstrumentInvalid{Message: "quantity step is not positive"}
+	case instrument.MinNotional.IsNegative():
+		return InstrumentInvalid{Message: "minimum notional is negative"}
+	}
+	instrument.UpdatedAt = time.Now()
+
+	err := s.instrumentStorage.SaveInstrument(instrument)
+	if err != nil {
+		err = errors.Wrap(err, "save instrument")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return err
+	}
+
+	s.mu.Lock()
+	s.instruments[OrderBookKey{ExchangeName: instrument.ExchangeName, Pair: instrument.Pair}] = *instrument
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *InstrumentService) GetInstrument(exchangeName, pair string) (*Instrument, error) {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	instrument, err := s.instrumentStorage.GetInstrument(exchangeName, pair)
+	switch err.(type) {
+	case nil:
+		return instrument, nil
+	case InstrumentNotFound:
+		return nil, err
+	default:
+		err = errors.Wrap(err, "get instrument")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+}
+
+// GetInstruments returns instruments of the exchange, of all exchanges if exchangeName is empty.
+func (s *InstrumentService) GetInstruments(exchangeName string) ([]Instrument, error) {
+	instruments, err := s.instrumentStorage.GetInstruments(exchangeName)
+	if err != nil {
+		err = errors.Wrap(err, "get instruments")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+	return instruments, nil
+}
+
+func (s *InstrumentService) DeleteInstrument(exchangeName, pair string) error {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	err := s.instrumentStorage.DeleteInstrument(exchangeName, pair)
+	switch err.(type) {
+	case nil, InstrumentNotFound:
+	default:
+		err = errors.Wrap(err, "delete instrument")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.instruments, OrderBookKey{ExchangeName: exchangeName, Pair: pair})
+	s.mu.Unlock()
+	return err
+}
+
+// LookupInstrument returns the in-memory copy of the instrument of a normalized pair.
+func (s *InstrumentService) LookupInstrument(exchangeName, pair string) (Instrument, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	instrument, ok := s.instruments[OrderBookKey{ExchangeName: exchangeName, Pair: pair}]
+	return instrument, ok
+}
+
+// Refresh replaces the in-memory copy with the stored instruments.
+func (s *InstrumentService) Refresh() error {
+	instruments, err := s.instrumentStorage.GetInstruments("")
+	if err != nil {
+		return errors.Wrap(err, "refresh instruments")
+	}
+
+	byKey := make(map[OrderBookKey]Instrument, len(instruments))
+	for _, instrument := range instruments {
+		byKey[OrderBookKey{ExchangeName: instrument.ExchangeName, Pair: instrument.Pair}] = instrument
+	}
+	s.mu.Lock()
+	s.instruments = byKey
+	s.mu.Unlock()
+	return nil
+}
+
+// Run refreshes instruments until ctx is done, it returns at once if refreshInterval
+// is not positive and instruments are only loaded at startup.
+func (s *InstrumentService) Run(ctx context.Context) {
+	if s.refreshInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.Refresh()
+			if err != nil {
+				slog.Error("", slogutils.ErrorAttr(err))
+			}
+		}
+	}
+}
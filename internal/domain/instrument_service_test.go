@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInstrumentServiceRunDisabled(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		service := NewInstrumentService(nil, NewSymbolNormalizer(nil, nil, nil), fakeExchangeLookup{}, interval)
+		requireReturns(t, func() { service.Run(context.Background()) })
+	}
+}
+
+// requireReturns fails the test if f blocks.
+func requireReturns(t *testing.T, f func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		f()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("did not return")
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentFormatDepthOrders(t *testing.T) {
+	instrument := &Instrument{
+		TickSize: decimal.RequireFromString("0.0010"),
+		QtyStep:  decimal.RequireFromString("1"),
+	}
+	testCases := []struct {
+		name      string
+		level     DepthOrder
+		formatted FormattedDepthOrder
+	}{
+		{name: "Padded", level: level("0.5", "2"), formatted: FormattedDepthOrder{Price: "0.500", BaseQty: "2"}},
+		{name: "OnTick", level: level("0.541", "10"), formatted: FormattedDepthOrder{Price: "0.541", BaseQty: "10"}},
+		{name: "TrailingZeros", level: level("0.54100", "3.0"), formatted: FormattedDepthOrder{Price: "0.541", BaseQty: "3"}},
+		{name: "OffTickPrice", level: level("0.5419", "2"), formatted: FormattedDepthOrder{Price: "0.5419", BaseQty: "2"}},
+		{name: "OffStepQty", level: level("0.5", "2.75"), formatted: FormattedDepthOrder{Price: "0.500", BaseQty: "2.75"}},
+		{name: "Whole", level: level("12", "1"), formatted: FormattedDepthOrder{Price: "12.000", BaseQty: "1"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatted := instrument.FormatDepthOrders([]DepthOrder{tc.level})
+			require.Equal(t, []FormattedDepthOrder{tc.formatted}, formatted)
+		})
+	}
+}
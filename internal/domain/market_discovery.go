@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type ExchangeSummary struct {
+	Name string `json:"name"`
+	// PairCount is the number of pairs with an order book or history orders.
+	PairCount      int `json:"pairCount"`
+	OrderBookCount int `json:"orderBookCount"`
+	// LastUpdatedAt is the latest update time of the exchange's order books.
+	LastUpdatedAt *time.Time `json:"lastUpdatedAt,omitempty"`
+}
+
+type PairSummary struct {
+	Pair               string            `json:"pair"`
+	Base               string            `json:"base,omitempty"`
+	Quote              string            `json:"quote,omitempty"`
+	OrderBook          *OrderBookSummary `json:"orderBook,omitempty"`
+	HistoryOrderCount  uint64            `json:"historyOrderCount"`
+	LastHistoryOrderAt *time.Time        `json:"lastHistoryOrderAt,omitempty"`
+}
+
+type OrderBookSummary struct {
+	UpdatedAt time.Time `json:"updatedAt"`
+	BidLevels int       `json:"bidLevels"`
+	AskLevels int       `json:"askLevels"`
+	Stale     bool      `json:"stale"`
+}
+
+type HistoryOrderSummary struct {
+	Count          uint64
+	LastTimePlaced time.Time
+}
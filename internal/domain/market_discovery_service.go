@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"log/slog"
+	"market-info-storage/internal/utils/slogutils"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// MarketDiscoveryService lists exchanges and pairs the service holds
+// order books or history orders for.
+type MarketDiscoveryService struct {
+	orderBookSummaryStorage    OrderBookSummaryStorage
+	historyOrderSummaryStorage HistoryOrderSummaryStorage
+	symbols                    *SymbolNormalizer
+}
+
+type OrderBookSummaryStorage interface {
+	// GetOrderBookSummaries returns summaries by exchange name and pair,
+	// of all exchanges if exchangeName is empty.
+	GetOrderBookSummaries(exchangeName string) (map[string]map[string]OrderBookSummary, error)
+}
+
+type HistoryOrderSummaryStorage interface {
+	// GetHistoryOrderSummaries returns summaries by exchange name and pair,
+	// of all exchanges if exchangeName is empty.
+	GetHistoryOrderSummaries(exchangeName string) (map[string]map[string]HistoryOrderSummary, error)
+}
+
+func NewMarketDiscoveryService(
+	orderBookSummaryStorage OrderBookSummaryStorage,
+	historyOrderSummaryStorage HistoryOrderSummaryStorage,
+	symbols *SymbolNormalizer,
+) *MarketDiscoveryService {
+	return &MarketDiscoveryService{
+		orderBookSummaryStorage:    orderBookSummaryStorage,
+		historyOrderSummaryStorage: historyOrderSummaryStorage,
+		symbols:                    symbols,
+	}
+}
+
+// GetExchanges returns exchanges with at least one pair matching filter sorted by name.
+func (s *MarketDiscoveryService) GetExchanges(filter AssetFilter) ([]ExchangeSummary, error) {
+	filter = s.normalizeFilter("", filter)
+	pairs, err := s.getPairs("")
+	if err != nil {
+		return nil, err
+	}
+
+	exchanges := make([]ExchangeSummary, 0, len(pairs))
+	for exchangeName, exchangePairs := range pairs {
+		exchange := ExchangeSummary{Name: exchangeName}
+		for _, pair := range exchangePairs {
+			if !filter.Match(pair.Pair) {
+				continue
+			}
+			exchange.PairCount++
+			if pair.OrderBook == nil {
+				continue
+			}
+			exchange.OrderBookCount++
+			if exchange.LastUpdatedAt == nil || pair.OrderBook.UpdatedAt.After(*exchange.LastUpdatedAt) {
+				updatedAt := pair.OrderBook.UpdatedAt
+				exchange.LastUpdatedAt = &updatedAt
+			}
+		}
+		if exchange.PairCount > 0 {
+			exchanges = append(exchanges, exchange)
+		}
+	}
+
+	sort.Slice(exchanges, func(i, j int) bool {
+		return exchanges[i].Name < exchanges[j].Name
+	})
+	return exchanges, nil
+}
+
+// GetExchangePairs returns pairs of the exchange matching filter sorted by name.
+func (s *MarketDiscoveryService) GetExchangePairs(exchangeName string, filter AssetFilter) ([]PairSummary, error) {
+	filter = s.normalizeFilter(exchangeName, filter)
+	pairs, err := s.getPairs(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pairs[exchangeName]) == 0 {
+		return nil, ExchangeNotFound{Message: "exchange not found"}
+	}
+
+	exchangePairs := make([]PairSummary, 0, len(pairs[exchangeName]))
+	for _, pair := range pairs[exchangeName] {
+		if filter.Match(pair.Pair) {
+			exchangePairs = append(exchangePairs, *pair)
+		}
+	}
+
+	sort.Slice(exchangePairs, func(i, j int) bool {
+		return exchangePairs[i].Pair < exchangePairs[j].Pair
+	})
+	return exchangePairs, nil
+}
+
+// normalizeFilter replaces asset aliases of the filter, XBT with BTC.
+func (s *MarketDiscoveryService) normalizeFilter(exchangeName string, filter AssetFilter) AssetFilter {
+	if filter.Base != "" {
+		filter.Base = s.symbols.NormalizeAsset(exchangeName, filter.Base)
+	}
+	if filter.Quote != "" {
+		filter.Quote = s.symbols.NormalizeAsset(exchangeName, filter.Quote)
+	}
+	return filter
+}
+
+// getPairs merges order book and history order summaries by exchange name and pair.
+func (s *MarketDiscoveryService) getPairs(exchangeName string) (map[string]map[string]*PairSummary, error) {
+	orderBookSummaries, err := s.orderBookSummaryStorage.GetOrderBookSummaries(exchangeName)
+	if err != nil {
+		err = errors.Wrap(err, "get order book summaries")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+	historyOrderSummaries, err := s.historyOrderSummaryStorage.GetHistoryOrderSummaries(exchangeName)
+	if err != nil {
+		err = errors.Wrap(err, "get history order summaries")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+
+	pairs := make(map[string]map[string]*PairSummary)
+	getPair := func(exchangeName, pair string) *PairSummary {
+		if pairs[exchangeName] == nil {
+			pairs[exchangeName] = make(map[string]*PairSummary)
+		}
+		if pairs[exchangeName][pair] == nil {
+			summary := &PairSummary{Pair: pair}
+			summary.Base, summary.Quote, _ = SplitPair(pair)
+			pairs[exchangeName][pair] = summary
+		}
+		return pairs[exchangeName][pair]
+	}
+	for exchangeName, exchangeSummaries := range orderBookSummaries {
+		for pair, orderBookSummary := range exchangeSummaries {
+			orderBookSummary := orderBookSummary
+			getPair(exchangeName, pair).OrderBook = &orderBookSummary
+		}
+	}
+	for exchangeName, exchangeSummaries := range historyOrderSummaries {
+		for pair, historyOrderSummary := range exchangeSummaries {
+			summary := getPair(exchangeName, pair)
+			summary.HistoryOrderCount = historyOrderSummary.Count
+			lastTimePlaced := historyOrderSummary.LastTimePlaced
+			summary.LastHistoryOrderAt = &lastTimePlaced
+		}
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type OrderBook struct {
+	Bids []DepthOrder
+	Asks []DepthOrder
+	// Sequence is the sequence number of the last snapshot or delta applied
+	// to the book, nil if the snapshot was saved without one.
+	Sequence *int64
+	// ResyncRequired is set when a delta arrived out of order. Deltas are
+	// rejected until a new snapshot is saved.
+	ResyncRequired bool
+	// UpdatedAt is the time the book was last saved or updated by a delta.
+	UpdatedAt time.Time
+	// ExchangeTime is the time the exchange reported for the last update, if any.
+	ExchangeTime *time.Time
+	// Stale is set by the sweeper when the book stops updating and cleared on the next update.
+	Stale bool
+	// Version is set by the storage on every save or update and grows across all books,
+	// so a book deleted and saved again never repeats a version.
+	Version int64
+	// Checksum is the checksum the exchange published with the book, it is verified
+	// on save and not stored.
+	Checksum *OrderBookChecksum
+}
+
+// SortLevels sorts bids by price in descending order and asks in ascending order, levels
+// with the same price keep their order. Deltas are applied to sorted levels only, while
+// the lenient validation mode accepts books with unsorted levels.
+func (ob *OrderBook) SortLevels() {
+	slices.SortStableFunc(ob.Bids, func(a, b DepthOrder) int { return b.Price.Cmp(a.Price) })
+	slices.SortStableFunc(ob.Asks, func(a, b DepthOrder) int { return a.Price.Cmp(b.Price) })
+}
+
+// OrderBookSnapshot is the state of an order book at the moment it was saved.
+type OrderBookSnapshot struct {
+	Time      time.Time
+	OrderBook *OrderBook
+	// Retention is how long the snapshot is kept after Time, 0 keeps it forever.
+	// It is set on save from the exchange's snapshot retention.
+	Retention time.Duration
+}
+
+// OrderBookReadOptions shape the levels returned by order book reads.
+type OrderBookReadOptions struct {
+	// Depth is the maximum number of levels per side, 0 means all levels.
+	Depth int
+	// Side restricts the result to one side, empty means both sides.
+	Side Side
+	// Group is the price step levels are grouped by, 0 disables grouping.
+	Group decimal.Decimal
+	// MaxAge rejects books updated longer ago with OrderBookStale, 0 disables the check.
+	MaxAge time.Duration
+}
+
+// ApplyReadOptions drops the side excluded by opts, groups levels and limits their number.
+func (ob *OrderBook) ApplyReadOptions(opts OrderBookReadOptions) {
+	switch opts.Side {
+	case SideBid:
+		ob.Asks = []DepthOrder{}
+	case SideAsk:
+		ob.Bids = []DepthOrder{}
+	}
+	if opts.Group.IsPositive() {
+		ob.Bids = GroupDepthOrders(SideBid, ob.Bids, opts.Group)
+		ob.Asks = GroupDepthOrders(SideAsk, ob.Asks, opts.Group)
+	}
+	if opts.Depth > 0 {
+		ob.Bids = ob.Bids[:min(opts.Depth, len(ob.Bids))]
+		ob.Asks = ob.Asks[:min(opts.Depth, len(ob.Asks))]
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+// OrderBookBatchItem is an order book of a pair on an exchange saved as part of a batch.
+type OrderBookBatchItem struct {
+	ExchangeName string
+	Pair         string
+	OrderBook    *OrderBook
+}
+
+// OrderBookBatchFailure describes an order book of a batch rejected by validation.
+type OrderBookBatchFailure struct {
+	// Index is the position of the book in the batch.
+	Index        int              `json:"index"`
+	ExchangeName string           `json:"exchange"`
+	Pair         string           `json:"pair"`
+	Error        string           `json:"error"`
+	Violations   []LevelViolation `json:"violations,omitempty"`
+}
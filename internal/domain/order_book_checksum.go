@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"expvar"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Checksum verification counters by exchange are served at /debug/vars.
+var (
+	orderBookChecksumsVerified   = expvar.NewMap("orderBookChecksumsVerified")
+	orderBookChecksumsMismatched = expvar.NewMap("orderBookChecksumsMismatched")
+)
+
+// OrderBookChecksum is the checksum an exchange published with a book update.
+type OrderBookChecksum struct {
+	// Algorithm names the checksum algorithm, the exchange name is used if empty.
+	Algorithm string
+	// Value is the CRC32 checksum, either signed as published by OKX or unsigned as published by Kraken.
+	Value int64
+}
+
+// checksumAlgorithms compute checksums of books the way the exchange they are named after does.
+var checksumAlgorithms = map[string]func(orderBook *OrderBook) uint32{
+	"okx":    okxChecksum,
+	"kraken": krakenChecksum,
+}
+
+// VerifyOrderBookChecksum compares the checksum with the one computed from the levels of the book.
+// OrderBookChecksumMismatch is returned on mismatch and ChecksumAlgorithmUnknown if there is no
+// algorithm with the checksum's name or, without a name, with the exchange name.
+func VerifyOrderBookChecksum(exchangeName string, orderBook *OrderBook, checksum *OrderBookChecksum) error {
+	algorithm := checksum.Algorithm
+	if algorithm == "" {
+		algorithm = exchangeName
+	}
+	compute, ok := checksumAlgorithms[algorithm]
+	if !ok {
+		return ChecksumAlgorithmUnknown{Message: fmt.Sprintf("unknown checksum algorithm %q", algorithm)}
+	}
+
+	computed := compute(orderBook)
+	if checksum.Value < -1<<31 || checksum.Value >= 1<<32 || uint32(checksum.Value) != computed {
+		orderBookChecksumsMismatched.Add(exchangeName, 1)
+		return OrderBookChecksumMismatch{Message: fmt.Sprintf(
+			"checksum %d does not match checksum %d computed with %s algorithm", checksum.Value, computed, algorithm)}
+	}
+	orderBookChecksumsVerified.Add(exchangeName, 1)
+	return nil
+}
+
+// okxChecksum is the CRC32 of the top 25 levels as bid price:bid size:ask price:ask size pairs
+// taken level by level, a side that has run out of levels is skipped.
+func okxChecksum(orderBook *OrderBook) uint32 {
+	const depth = 25
+	var fields []string
+	for i := 0; i < depth; i++ {
+		if i < len(orderBook.Bids) {
+			fields = append(fields, exchangeString(orderBook.Bids[i].Price), exchangeString(orderBook.Bids[i].BaseQty))
+		}
+		if i < len(orderBook.Asks) {
+			fields = append(fields, exchangeString(orderBook.Asks[i].Price), exchangeString(orderBook.Asks[i].BaseQty))
+		}
+	}
+	return crc32.ChecksumIEEE([]byte(strings.Join(fields, ":")))
+}
+
+// krakenChecksum is the CRC32 of the top 10 asks followed by the top 10 bids, every price and
+// quantity written without the decimal point and leading zeros.
+func krakenChecksum(orderBook *OrderBook) uint32 {
+	const depth = 10
+	var sb strings.Builder
+	for _, depthOrders := range [][]DepthOrder{orderBook.Asks, orderBook.Bids} {
+		for _, depthOrder := range depthOrders[:min(depth, len(depthOrders))] {
+			for _, d := range []decimal.Decimal{depthOrder.Price, depthOrder.BaseQty} {
+				sb.WriteString(strings.TrimLeft(strings.Replace(exchangeString(d), ".", "", 1), "0"))
+			}
+		}
+	}
+	return crc32.ChecksumIEEE([]byte(sb.String()))
+}
+
+// exchangeString keeps the trailing zeros the level was received with, checksums of 0.10
+// and 0.1 differ. Decimals keep the number of decimal places they were parsed with.
+func exchangeString(d decimal.Decimal) string {
+	if d.Exponent() >= 0 {
+		return d.String()
+	}
+	return d.StringFixed(-d.Exponent())
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// OrderBookDelta holds level upserts. A level with zero BaseQty removes
+// the level with the same price.
+type OrderBookDelta struct {
+	Sequence int64
+	Bids     []DepthOrder
+	Asks     []DepthOrder
+	// ExchangeTime is the time the exchange reported for the delta, if any.
+	ExchangeTime *time.Time
+	// Checksum is the checksum the exchange published for the book with the delta applied.
+	Checksum *OrderBookChecksum
+}
+
+// ApplyDelta applies delta to the order book if delta.Sequence directly follows
+// the book's sequence number. Otherwise the book is marked as requiring resync
+// and OrderBookResyncRequired is returned with levels left untouched.
+func (ob *OrderBook) ApplyDelta(delta *OrderBookDelta) error {
+	if ob.ResyncRequired {
+		return OrderBookResyncRequired{Message: "order book requires a new snapshot"}
+	}
+	if ob.Sequence != nil && delta.Sequence != *ob.Sequence+1 {
+		ob.ResyncRequired = true
+		return OrderBookResyncRequired{Message: fmt.Sprintf(
+			"expected delta sequence %d, got %d, order book requires a new snapshot",
+			*ob.Sequence+1, delta.Sequence)}
+	}
+
+	for _, depthOrder := range delta.Bids {
+		ob.Bids = upsertDepthOrder(ob.Bids, depthOrder, decimal.Decimal.GreaterThan)
+	}
+	for _, depthOrder := range delta.Asks {
+		ob.Asks = upsertDepthOrder(ob.Asks, depthOrder, decimal.Decimal.LessThan)
+	}
+	sequence := delta.Sequence
+	ob.Sequence = &sequence
+	if delta.ExchangeTime != nil {
+		ob.ExchangeTime = delta.ExchangeTime
+	}
+
+	return nil
+}
+
+// upsertDepthOrder keeps depthOrders sorted by price according to less.
+func upsertDepthOrder(depthOrders []DepthOrder, depthOrder DepthOrder, less func(a, b decimal.Decimal) bool) []DepthOrder {
+	i := sort.Search(len(depthOrders), func(i int) bool {
+		return !less(depthOrders[i].Price, depthOrder.Price)
+	})
+	found := i < len(depthOrders) && depthOrders[i].Price.Equal(depthOrder.Price)
+
+	switch {
+	case depthOrder.BaseQty.IsZero() && found:
+		return append(depthOrders[:i], depthOrders[i+1:]...)
+	case depthOrder.BaseQty.IsZero():
+		return depthOrders
+	case found:
+		depthOrders[i] = depthOrder
+		return depthOrders
+	default:
+		depthOrders = append(depthOrders, DepthOrder{})
+		copy(depthOrders[i+1:], depthOrders[i:])
+		depthOrders[i] = depthOrder
+		return depthOrders
+	}
+}
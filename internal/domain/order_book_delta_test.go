@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDelta(t *testing.T) {
+	testCases := []struct {
+		name           string
+		sequence       *int64
+		resyncRequired bool
+		delta          OrderBookDelta
+		bids           []DepthOrder
+		asks           []DepthOrder
+		resync         bool
+	}{
+		{
+			name:     "NextSequence",
+			sequence: sequencePtr(10),
+			delta:    OrderBookDelta{Sequence: 11, Bids: []DepthOrder{level("100", "3")}},
+			bids:     []DepthOrder{level("100", "3"), level("99", "2")},
+			asks:     []DepthOrder{level("101", "1"), level("102", "2")},
+		},
+		{
+			name:     "NoSequence",
+			sequence: nil,
+			delta:    OrderBookDelta{Sequence: 42, Asks: []DepthOrder{level("101", "5")}},
+			bids:     []DepthOrder{level("100", "1"), level("99", "2")},
+			asks:     []DepthOrder{level("101", "5"), level("102", "2")},
+		},
+		{
+			name:     "Gap",
+			sequence: sequencePtr(10),
+			delta:    OrderBookDelta{Sequence: 12, Bids: []DepthOrder{level("100", "3")}},
+			bids:     []DepthOrder{level("100", "1"), level("99", "2")},
+			asks:     []DepthOrder{level("101", "1"), level("102", "2")},
+			resync:   true,
+		},
+		{
+			name:     "Replayed",
+			sequence: sequencePtr(10),
+			delta:    OrderBookDelta{Sequence: 10, Bids: []DepthOrder{level("100", "3")}},
+			bids:     []DepthOrder{level("100", "1"), level("99", "2")},
+			asks:     []DepthOrder{level("101", "1"), level("102", "2")},
+			resync:   true,
+		},
+		{
+			name:           "ResyncRequired",
+			sequence:       sequencePtr(10),
+			resyncRequired: true,
+			delta:          OrderBookDelta{Sequence: 11, Bids: []DepthOrder{level("100", "3")}},
+			bids:           []DepthOrder{level("100", "1"), level("99", "2")},
+			asks:           []DepthOrder{level("101", "1"), level("102", "2")},
+			resync:         true,
+		},
+		{
+			name:     "InsertKeepsOrder",
+			sequence: sequencePtr(10),
+			delta: OrderBookDelta{
+				Sequence: 11,
+				Bids:     []DepthOrder{level("99.5", "1"), level("101", "1"), level("98", "1")},
+				Asks:     []DepthOrder{level("101.5", "1"), level("100.5", "1"), level("103", "1")},
+			},
+			bids: []DepthOrder{level("101", "1"), level("100", "1"), level("99.5", "1"), level("99", "2"), level("98", "1")},
+			asks: []DepthOrder{level("100.5", "1"), level("101", "1"), level("101.5", "1"), level("102", "2"), level("103", "1")},
+		},
+		{
+			name:     "RemoveLevels",
+			sequence: sequencePtr(10),
+			delta: OrderBookDelta{
+				Sequence: 11,
+				Bids:     []DepthOrder{level("100.00", "0"), level("50", "0")},
+				Asks:     []DepthOrder{level("102", "0")},
+			},
+			bids: []DepthOrder{level("99", "2")},
+			asks: []DepthOrder{level("101", "1")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			orderBook := &OrderBook{
+				Bids:           []DepthOrder{level("100", "1"), level("99", "2")},
+				Asks:           []DepthOrder{level("101", "1"), level("102", "2")},
+				Sequence:       tc.sequence,
+				ResyncRequired: tc.resyncRequired,
+			}
+
+			err := orderBook.ApplyDelta(&tc.delta)
+			if tc.resync {
+				require.IsType(t, OrderBookResyncRequired{}, err)
+				require.True(t, orderBook.ResyncRequired)
+				require.Equal(t, tc.sequence, orderBook.Sequence)
+			} else {
+				require.NoError(t, err)
+				require.False(t, orderBook.ResyncRequired)
+				require.Equal(t, tc.delta.Sequence, *orderBook.Sequence)
+			}
+			requireLevels(t, tc.bids, orderBook.Bids)
+			requireLevels(t, tc.asks, orderBook.Asks)
+		})
+	}
+}
+
+func sequencePtr(sequence int64) *int64 {
+	return &sequence
+}
+
+// requireLevels compares levels by value as 100 and 100.00 are the same price.
+func requireLevels(t *testing.T, expected, actual []DepthOrder) {
+	t.Helper()
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.True(t, expected[i].Price.Equal(actual[i].Price), "level %d: price %s != %s", i, expected[i].Price, actual[i].Price)
+		require.True(t, expected[i].BaseQty.Equal(actual[i].BaseQty), "level %d: qty %s != %s", i, expected[i].BaseQty, actual[i].BaseQty)
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"log/slog"
+	"market-info-storage/internal/utils/slogutils"
+
+	"github.com/pkg/errors"
+)
+
+// OrderBookChange is a notification about an order book saved or deleted by any instance.
+type OrderBookChange struct {
+	ExchangeName string `json:"exchange"`
+	Pair         string `json:"pair"`
+	Version      int64  `json:"version"`
+	Deleted      bool   `json:"deleted"`
+}
+
+// OrderBookFanout publishes order books changed by other instances to the local hub.
+type OrderBookFanout struct {
+	storage OrderBookFanoutStorage
+	hub     *OrderBookHub
+}
+
+type OrderBookFanoutStorage interface {
+	GetOrderBook(exchangeName, pair string, opts OrderBookReadOptions) (*OrderBook, error)
+	// GetOrderBookVersions returns versions of all stored order books.
+	GetOrderBookVersions() (map[OrderBookKey]int64, error)
+}
+
+func NewOrderBookFanout(storage OrderBookFanoutStorage, hub *OrderBookHub) *OrderBookFanout {
+	return &OrderBookFanout{
+		storage: storage,
+		hub:     hub,
+	}
+}
+
+// HandleOrderBookChange loads and publishes the changed book unless its version
+// was already published, which is the case for books saved by this instance.
+// Books flagged stale by the sweeper of any instance are published from here.
+// Deletions by any instance, including this one, are published from here.
+func (f *OrderBookFanout) HandleOrderBookChange(change OrderBookChange) {
+	if change.Deleted {
+		f.hub.PublishOrderBookDeletion(change.ExchangeName, change.Pair, change.Version)
+		return
+	}
+	key := OrderBookKey{ExchangeName: change.ExchangeName, Pair: change.Pair}
+	if change.Version <= f.hub.OrderBookVersion(key) {
+		return
+	}
+	f.publish(key)
+}
+
+// ResyncOrderBooks publishes all books whose latest versions were not published,
+// it is called when change notifications may have been lost.
+func (f *OrderBookFanout) ResyncOrderBooks() {
+	versions, err := f.storage.GetOrderBookVersions()
+	if err != nil {
+		err = errors.Wrap(err, "get order book versions")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return
+	}
+
+	for key, version := range versions {
+		if version > f.hub.OrderBookVersion(key) {
+			f.publish(key)
+		}
+	}
+}
+
+func (f *OrderBookFanout) publish(key OrderBookKey) {
+	orderBook, err := f.storage.GetOrderBook(key.ExchangeName, key.Pair, OrderBookReadOptions{})
+	switch err.(type) {
+	case nil:
+		f.hub.PublishOrderBook(key.ExchangeName, key.Pair, orderBook)
+	case OrderBookNotFound:
+		// deleted after the change
+	default:
+		err = errors.Wrap(err, "get changed order book")
+		slog.Error("", slogutils.ErrorAttr(err))
+	}
+}
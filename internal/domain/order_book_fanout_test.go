@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderBookFanoutPublishesDeletion(t *testing.T) {
+	hub := NewOrderBookHub(16)
+	key := OrderBookKey{ExchangeName: "binance", Pair: "LUNA_USDT"}
+	sub := hub.Subscribe([]OrderBookKey{key}, false)
+	defer sub.Close()
+	eventLog, err := NewEventLog(16)
+	require.NoError(t, err)
+	hub.AddListener(eventLog.PublishOrderBookUpdate)
+	fanout := NewOrderBookFanout(nil, hub)
+
+	hub.PublishOrderBook("binance", "LUNA_USDT", &OrderBook{Bids: []DepthOrder{level("0.5", "1")}, Version: 7})
+	fanout.HandleOrderBookChange(OrderBookChange{ExchangeName: "binance", Pair: "LUNA_USDT", Version: 7, Deleted: true})
+	// a repeated notification and a deletion older than the published version are dropped
+	fanout.HandleOrderBookChange(OrderBookChange{ExchangeName: "binance", Pair: "LUNA_USDT", Version: 7, Deleted: true})
+	fanout.HandleOrderBookChange(OrderBookChange{ExchangeName: "binance", Pair: "LUNA_USDT", Version: 6, Deleted: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	updates, err := sub.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	require.False(t, updates[0].Deleted)
+	require.True(t, updates[1].Deleted)
+	require.Equal(t, int64(7), updates[1].OrderBook.Version)
+	require.Empty(t, updates[1].OrderBook.Bids)
+
+	events, ok := eventLog.eventsSince(0)
+	require.True(t, ok)
+	require.Len(t, events, 2)
+	require.Equal(t, EventTypeOrderBook, events[0].Type)
+	require.Equal(t, EventTypeOrderBookDeleted, events[1].Type)
+
+	// the book saved again after the deletion is published
+	hub.PublishOrderBook("binance", "LUNA_USDT", &OrderBook{Version: 8})
+	updates, err = sub.Next(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	require.False(t, updates[0].Deleted)
+}
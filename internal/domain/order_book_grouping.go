@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// GroupDepthOrders buckets levels into price steps of tick, rounding bid prices down
+// and ask prices up, and sums BaseQty per bucket. The result is sorted best price first.
+func GroupDepthOrders(side Side, depthOrders []DepthOrder, tick decimal.Decimal) []DepthOrder {
+	// buckets are keyed by the bucket price, every bucket price has the exponent of tick
+	buckets := make(map[string]*DepthOrder, len(depthOrders))
+	for _, depthOrder := range depthOrders {
+		ticks := depthOrder.Price.Div(tick)
+		if side == SideBid {
+			ticks = ticks.Floor()
+		} else {
+			ticks = ticks.Ceil()
+		}
+		price := ticks.Mul(tick)
+		if bucket, ok := buckets[price.String()]; ok {
+			bucket.BaseQty = bucket.BaseQty.Add(depthOrder.BaseQty)
+		} else {
+			buckets[price.String()] = &DepthOrder{Price: price, BaseQty: depthOrder.BaseQty}
+		}
+	}
+
+	grouped := make([]DepthOrder, 0, len(buckets))
+	for _, bucket := range buckets {
+		grouped = append(grouped, *bucket)
+	}
+	sort.Slice(grouped, func(i, j int) bool {
+		if side == SideBid {
+			return grouped[i].Price.GreaterThan(grouped[j].Price)
+		}
+		return grouped[i].Price.LessThan(grouped[j].Price)
+	})
+
+	return grouped
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestGroupDepthOrders(t *testing.T) {
+	testCases := []struct {
+		name        string
+		side        Side
+		tick        string
+		depthOrders []DepthOrder
+		grouped     []DepthOrder
+	}{
+		{
+			name:        "BidsRoundDown",
+			side:        SideBid,
+			tick:        "1",
+			depthOrders: []DepthOrder{level("100.9", "1"), level("100.1", "2"), level("99.5", "3")},
+			grouped:     []DepthOrder{level("100", "3"), level("99", "3")},
+		},
+		{
+			name:        "AsksRoundUp",
+			side:        SideAsk,
+			tick:        "1",
+			depthOrders: []DepthOrder{level("100.1", "1"), level("100.9", "2"), level("101.5", "3")},
+			grouped:     []DepthOrder{level("101", "3"), level("102", "3")},
+		},
+		{
+			name:        "OnTickKeepsPrice",
+			side:        SideAsk,
+			tick:        "0.5",
+			depthOrders: []DepthOrder{level("100", "1"), level("100.5", "2")},
+			grouped:     []DepthOrder{level("100", "1"), level("100.5", "2")},
+		},
+		{
+			name:        "FractionalTickIsExact",
+			side:        SideBid,
+			tick:        "0.1",
+			depthOrders: []DepthOrder{level("0.3", "1"), level("0.29", "2"), level("0.2", "3")},
+			grouped:     []DepthOrder{level("0.3", "1"), level("0.2", "5")},
+		},
+		{
+			name:        "UnsortedInput",
+			side:        SideBid,
+			tick:        "10",
+			depthOrders: []DepthOrder{level("85", "1"), level("101", "2"), level("89", "3")},
+			grouped:     []DepthOrder{level("100", "2"), level("80", "4")},
+		},
+		{
+			// the number of ticks does not fit into int64
+			name:        "TinyTick",
+			side:        SideAsk,
+			tick:        "0.000000000000000000001",
+			depthOrders: []DepthOrder{level("60000", "1"), level("60000.000000000000000000001", "2")},
+			grouped:     []DepthOrder{level("60000", "1"), level("60000.000000000000000000001", "2")},
+		},
+		{
+			name:        "HugePrice",
+			side:        SideBid,
+			tick:        "1000",
+			depthOrders: []DepthOrder{level("99999999999999999999999.5", "1"), level("99999999999999999999001", "2")},
+			grouped:     []DepthOrder{level("99999999999999999999000", "3")},
+		},
+		{
+			name:        "Empty",
+			side:        SideBid,
+			tick:        "1",
+			depthOrders: []DepthOrder{},
+			grouped:     []DepthOrder{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			grouped := GroupDepthOrders(tc.side, tc.depthOrders, decimal.RequireFromString(tc.tick))
+			requireLevels(t, tc.grouped, grouped)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+type OrderBookKey struct {
+	ExchangeName string
+	Pair         string
+}
+
+// OrderBookUpdate is the state of an order book right after it was saved or updated.
+type OrderBookUpdate struct {
+	ExchangeName string
+	Pair         string
+	OrderBook    *OrderBook
+	// Deleted is set when the book was deleted, OrderBook then has no levels
+	// and holds the last version of the book.
+	Deleted bool
+}
+
+// OrderBookHub fans order book updates out to subscriptions and listeners in the process.
+type OrderBookHub struct {
+	mu            sync.RWMutex
+	subscriptions map[OrderBookKey]map[*OrderBookSubscription]struct{}
+	listeners     []func(update OrderBookUpdate)
+	bufferSize    int
+
+	// publishMu serializes publishing from the version check on, so that subscribers
+	// and listeners receive the versions of a book in ascending order.
+	publishMu sync.Mutex
+	// versions are the latest published versions, an update with an older
+	// or the same version is dropped as it was already published.
+	versions map[OrderBookKey]int64
+	// deleted holds books whose deletion was published after their latest version.
+	deleted map[OrderBookKey]bool
+}
+
+// NewOrderBookHub creates a hub whose subscriptions queue up to bufferSize updates.
+func NewOrderBookHub(bufferSize int) *OrderBookHub {
+	return &OrderBookHub{
+		subscriptions: make(map[OrderBookKey]map[*OrderBookSubscription]struct{}),
+		bufferSize:    bufferSize,
+		versions:      make(map[OrderBookKey]int64),
+		deleted:       make(map[OrderBookKey]bool),
+	}
+}
+
+// Subscribe subscribes to updates of the books with keys. A conflating subscription
+// keeps only the latest state of every book for slow readers, otherwise the subscription
+// is closed with OrderBookSubscriptionOverflow once its queue is full.
+func (h *OrderBookHub) Subscribe(keys []OrderBookKey, conflate bool) *OrderBookSubscription {
+	sub := &OrderBookSubscription{
+		hub:        h,
+		keys:       keys,
+		conflate:   conflate,
+		bufferSize: h.bufferSize,
+		latest:     make(map[OrderBookKey]int),
+		ready:      make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, key := range keys {
+		if h.subscriptions[key] == nil {
+			h.subscriptions[key] = make(map[*OrderBookSubscription]struct{})
+		}
+		h.subscriptions[key][sub] = struct{}{}
+	}
+	return sub
+}
+
+// AddListener registers listener to be called with updates of all books.
+// Listeners are called synchronously on publishing and must not block.
+func (h *OrderBookHub) AddListener(listener func(update OrderBookUpdate)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listeners = append(h.listeners, listener)
+}
+
+// PublishOrderBook publishes the order book unless its version was already published.
+// Books without a version are always published.
+func (h *OrderBookHub) PublishOrderBook(exchangeName, pair string, orderBook *OrderBook) {
+	key := OrderBookKey{ExchangeName: exchangeName, Pair: pair}
+	h.publishMu.Lock()
+	defer h.publishMu.Unlock()
+	if !h.advanceVersion(key, orderBook.Version) {
+		return
+	}
+	h.publish(OrderBookUpdate{
+		ExchangeName: exchangeName,
+		Pair:         pair,
+		OrderBook:    orderBook,
+	})
+}
+
+// PublishOrderBookDeletion publishes that the book with version was deleted unless
+// the deletion was already published or a newer version of the book was published since.
+func (h *OrderBookHub) PublishOrderBookDeletion(exchangeName, pair string, version int64) {
+	key := OrderBookKey{ExchangeName: exchangeName, Pair: pair}
+	h.publishMu.Lock()
+	defer h.publishMu.Unlock()
+	if version < h.versions[key] || version == h.versions[key] && h.deleted[key] {
+		return
+	}
+	h.versions[key] = version
+	h.deleted[key] = true
+
+	h.publish(OrderBookUpdate{
+		ExchangeName: exchangeName,
+		Pair:         pair,
+		OrderBook:    &OrderBook{Version: version},
+		Deleted:      true,
+	})
+}
+
+func (h *OrderBookHub) publish(update OrderBookUpdate) {
+	key := OrderBookKey{ExchangeName: update.ExchangeName, Pair: update.Pair}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.subscriptions[key] {
+		sub.push(update)
+	}
+	for _, listener := range h.listeners {
+		listener(update)
+	}
+}
+
+// OrderBookVersion returns the latest published version of the book, 0 if none was published.
+func (h *OrderBookHub) OrderBookVersion(key OrderBookKey) int64 {
+	h.publishMu.Lock()
+	defer h.publishMu.Unlock()
+	return h.versions[key]
+}
+
+// advanceVersion records version as the latest published one, publishMu must be held.
+func (h *OrderBookHub) advanceVersion(key OrderBookKey, version int64) bool {
+	if version == 0 {
+		return true
+	}
+	if version <= h.versions[key] {
+		return false
+	}
+	h.versions[key] = version
+	delete(h.deleted, key)
+	return true
+}
+
+func (h *OrderBookHub) unsubscribe(sub *OrderBookSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, key := range sub.keys {
+		delete(h.subscriptions[key], sub)
+		if len(h.subscriptions[key]) == 0 {
+			delete(h.subscriptions, key)
+		}
+	}
+}
+
+type OrderBookSubscription struct {
+	hub        *OrderBookHub
+	keys       []OrderBookKey
+	conflate   bool
+	bufferSize int
+
+	mu      sync.Mutex
+	pending []OrderBookUpdate
+	// latest holds indexes of pending updates by book when conflating.
+	latest   map[OrderBookKey]int
+	overflow bool
+	ready    chan struct{}
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func (s *OrderBookSubscription) push(update OrderBookUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.overflow {
+		return
+	}
+
+	key := OrderBookKey{ExchangeName: update.ExchangeName, Pair: update.Pair}
+	if i, ok := s.latest[key]; ok && s.conflate {
+		s.pending[i] = update
+		return
+	}
+	if len(s.pending) >= s.bufferSize && !s.conflate {
+		s.overflow = true
+		s.pending = nil
+	} else {
+		if s.conflate {
+			s.latest[key] = len(s.pending)
+		}
+		s.pending = append(s.pending, update)
+	}
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Next waits for updates and returns all updates queued since the previous call.
+func (s *OrderBookSubscription) Next(ctx context.Context) ([]OrderBookUpdate, error) {
+	for {
+		s.mu.Lock()
+		if s.overflow {
+			s.mu.Unlock()
+			return nil, OrderBookSubscriptionOverflow{Message: "subscriber is too slow to receive updates"}
+		}
+		if len(s.pending) > 0 {
+			updates := s.pending
+			s.pending = nil
+			clear(s.latest)
+			s.mu.Unlock()
+			return updates, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.done:
+			return nil, context.Canceled
+		case <-s.ready:
+		}
+	}
+}
+
+func (s *OrderBookSubscription) Close() {
+	s.closeOnce.Do(func() {
+		s.hub.unsubscribe(s)
+		close(s.done)
+	})
+}
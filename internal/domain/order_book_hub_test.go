@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderBookHubPublishesVersionsInOrder(t *testing.T) {
+	hub := NewOrderBookHub(16)
+	entered, release := make(chan struct{}), make(chan struct{})
+	var mu sync.Mutex
+	var versions []int64
+	hub.AddListener(func(update OrderBookUpdate) {
+		if update.OrderBook.Version == 2 {
+			close(entered)
+			<-release
+		}
+		mu.Lock()
+		versions = append(versions, update.OrderBook.Version)
+		mu.Unlock()
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		hub.PublishOrderBook("bybit", "BTC_USDT", &OrderBook{Version: 2})
+	}()
+	<-entered
+	// a newer version saved concurrently waits until the older one is published
+	go func() {
+		defer wg.Done()
+		hub.PublishOrderBook("bybit", "BTC_USDT", &OrderBook{Version: 3})
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, []int64{2, 3}, versions)
+}
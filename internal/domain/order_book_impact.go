@@ -0,0 +1,91 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "buy"
+	OrderSideSell OrderSide = "sell"
+)
+
+// MarketImpact is the estimated execution of a market order against an order book.
+type MarketImpact struct {
+	Side OrderSide `json:"side"`
+	// FilledBaseQty and FilledQuoteQty are the executed amounts.
+	FilledBaseQty  float64 `json:"filledBaseQty"`
+	FilledQuoteQty float64 `json:"filledQuoteQty"`
+	// VWAPPrice is the volume weighted fill price, nil if nothing is filled.
+	VWAPPrice *float64 `json:"vwapPrice"`
+	// WorstPrice is the exact price of the last level reached, nil if nothing is filled.
+	WorstPrice     *decimal.Decimal `json:"worstPrice" swaggertype:"string"`
+	LevelsConsumed int              `json:"levelsConsumed"`
+	// SlippageBps is the cost of VWAPPrice relative to the mid price in basis points,
+	// nil if nothing is filled or one of the book sides is empty.
+	SlippageBps *float64 `json:"slippageBps"`
+	// UnfilledBaseQty or UnfilledQuoteQty, whichever the order was sized in,
+	// is the remainder the book was not deep enough for.
+	UnfilledBaseQty  *float64 `json:"unfilledBaseQty,omitempty"`
+	UnfilledQuoteQty *float64 `json:"unfilledQuoteQty,omitempty"`
+}
+
+// EstimateMarketImpact walks asks for buy orders and bids for sell orders.
+// The order is sized either in base quantity or in quote notional, the other one must be 0.
+// Being an estimate it is computed in float64, only WorstPrice is the level price as stored.
+func (ob *OrderBook) EstimateMarketImpact(side OrderSide, baseQty, quoteQty float64) MarketImpact {
+	impact := MarketImpact{Side: side}
+	depthOrders := ob.Asks
+	if side == OrderSideSell {
+		depthOrders = ob.Bids
+	}
+
+	remainingBase, remainingQuote := baseQty, quoteQty
+	sizedInQuote := quoteQty > 0
+	for _, depthOrder := range depthOrders {
+		if sizedInQuote && remainingQuote <= 0 || !sizedInQuote && remainingBase <= 0 {
+			break
+		}
+
+		price := depthOrder.Price.InexactFloat64()
+		fillBase, fillQuote := depthOrder.BaseQty.InexactFloat64(), depthOrder.BaseQty.Mul(depthOrder.Price).InexactFloat64()
+		switch {
+		case sizedInQuote && fillQuote > remainingQuote:
+			fillBase, fillQuote = remainingQuote/price, remainingQuote
+		case !sizedInQuote && fillBase > remainingBase:
+			fillBase, fillQuote = remainingBase, remainingBase*price
+		}
+		remainingBase -= fillBase
+		remainingQuote -= fillQuote
+
+		impact.FilledBaseQty += fillBase
+		impact.FilledQuoteQty += fillQuote
+		impact.LevelsConsumed++
+		worstPrice := depthOrder.Price
+		impact.WorstPrice = &worstPrice
+	}
+
+	if sizedInQuote {
+		unfilled := max(remainingQuote, 0)
+		impact.UnfilledQuoteQty = &unfilled
+	} else {
+		unfilled := max(remainingBase, 0)
+		impact.UnfilledBaseQty = &unfilled
+	}
+
+	if impact.FilledBaseQty == 0 {
+		return impact
+	}
+	vwapPrice := impact.FilledQuoteQty / impact.FilledBaseQty
+	impact.VWAPPrice = &vwapPrice
+
+	if len(ob.Bids) > 0 && len(ob.Asks) > 0 {
+		mid := ob.Bids[0].Price.Add(ob.Asks[0].Price).InexactFloat64() / 2
+		slippageBps := (vwapPrice - mid) / mid * bps
+		if side == OrderSideSell {
+			slippageBps = -slippageBps
+		}
+		impact.SlippageBps = &slippageBps
+	}
+
+	return impact
+}
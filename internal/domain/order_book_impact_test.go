@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateMarketImpact(t *testing.T) {
+	book := &OrderBook{
+		Bids: []DepthOrder{level("99", "1"), level("98", "2")},
+		Asks: []DepthOrder{level("101", "1"), level("102", "2")},
+	}
+	testCases := []struct {
+		name      string
+		orderBook *OrderBook
+		side      OrderSide
+		baseQty   float64
+		quoteQty  float64
+		impact    MarketImpact
+	}{
+		{
+			name:      "BuyBase",
+			orderBook: book,
+			side:      OrderSideBuy,
+			baseQty:   2,
+			impact: MarketImpact{
+				FilledBaseQty: 2, FilledQuoteQty: 203, VWAPPrice: floatPtr(101.5), WorstPrice: decimalPtr("102"),
+				LevelsConsumed: 2, SlippageBps: floatPtr(150), UnfilledBaseQty: floatPtr(0),
+			},
+		},
+		{
+			name:      "BuyBaseWithinLevel",
+			orderBook: book,
+			side:      OrderSideBuy,
+			baseQty:   0.5,
+			impact: MarketImpact{
+				FilledBaseQty: 0.5, FilledQuoteQty: 50.5, VWAPPrice: floatPtr(101), WorstPrice: decimalPtr("101"),
+				LevelsConsumed: 1, SlippageBps: floatPtr(100), UnfilledBaseQty: floatPtr(0),
+			},
+		},
+		{
+			name:      "BuyBaseUnfilledRemainder",
+			orderBook: book,
+			side:      OrderSideBuy,
+			baseQty:   5,
+			impact: MarketImpact{
+				FilledBaseQty: 3, FilledQuoteQty: 305, VWAPPrice: floatPtr(305.0 / 3), WorstPrice: decimalPtr("102"),
+				LevelsConsumed: 2, SlippageBps: floatPtr((305.0/3 - 100) * 100), UnfilledBaseQty: floatPtr(2),
+			},
+		},
+		{
+			name:      "SellBase",
+			orderBook: book,
+			side:      OrderSideSell,
+			baseQty:   1.5,
+			impact: MarketImpact{
+				FilledBaseQty: 1.5, FilledQuoteQty: 148, VWAPPrice: floatPtr(148 / 1.5), WorstPrice: decimalPtr("98"),
+				LevelsConsumed: 2, SlippageBps: floatPtr((100 - 148/1.5) * 100), UnfilledBaseQty: floatPtr(0),
+			},
+		},
+		{
+			name:      "BuyQuote",
+			orderBook: book,
+			side:      OrderSideBuy,
+			quoteQty:  152,
+			impact: MarketImpact{
+				FilledBaseQty: 1.5, FilledQuoteQty: 152, VWAPPrice: floatPtr(152 / 1.5), WorstPrice: decimalPtr("102"),
+				LevelsConsumed: 2, SlippageBps: floatPtr((152/1.5 - 100) * 100), UnfilledQuoteQty: floatPtr(0),
+			},
+		},
+		{
+			name:      "SellQuoteUnfilledRemainder",
+			orderBook: book,
+			side:      OrderSideSell,
+			quoteQty:  400,
+			impact: MarketImpact{
+				FilledBaseQty: 3, FilledQuoteQty: 295, VWAPPrice: floatPtr(295.0 / 3), WorstPrice: decimalPtr("98"),
+				LevelsConsumed: 2, SlippageBps: floatPtr((100 - 295.0/3) * 100), UnfilledQuoteQty: floatPtr(105),
+			},
+		},
+		{
+			name:      "EmptySide",
+			orderBook: &OrderBook{Bids: []DepthOrder{level("99", "1")}},
+			side:      OrderSideBuy,
+			baseQty:   1,
+			impact:    MarketImpact{UnfilledBaseQty: floatPtr(1)},
+		},
+		{
+			name:      "OneSidedBook",
+			orderBook: &OrderBook{Asks: []DepthOrder{level("101", "1")}},
+			side:      OrderSideBuy,
+			baseQty:   1,
+			impact: MarketImpact{
+				FilledBaseQty: 1, FilledQuoteQty: 101, VWAPPrice: floatPtr(101), WorstPrice: decimalPtr("101"),
+				LevelsConsumed: 1, UnfilledBaseQty: floatPtr(0),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			impact := tc.orderBook.EstimateMarketImpact(tc.side, tc.baseQty, tc.quoteQty)
+
+			require.Equal(t, tc.side, impact.Side)
+			require.InDelta(t, tc.impact.FilledBaseQty, impact.FilledBaseQty, 1e-9)
+			require.InDelta(t, tc.impact.FilledQuoteQty, impact.FilledQuoteQty, 1e-9)
+			require.Equal(t, tc.impact.LevelsConsumed, impact.LevelsConsumed)
+			requireFloatPtr(t, tc.impact.VWAPPrice, impact.VWAPPrice, "vwapPrice")
+			requireDecimalPtr(t, tc.impact.WorstPrice, impact.WorstPrice, "worstPrice")
+			requireFloatPtr(t, tc.impact.SlippageBps, impact.SlippageBps, "slippageBps")
+			requireFloatPtr(t, tc.impact.UnfilledBaseQty, impact.UnfilledBaseQty, "unfilledBaseQty")
+			requireFloatPtr(t, tc.impact.UnfilledQuoteQty, impact.UnfilledQuoteQty, "unfilledQuoteQty")
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func requireFloatPtr(t *testing.T, expected, actual *float64, name string) {
+	t.Helper()
+	if expected == nil {
+		require.Nil(t, actual, name)
+		return
+	}
+	require.NotNil(t, actual, name)
+	require.InDelta(t, *expected, *actual, 1e-9, name)
+}
+
+func decimalPtr(s string) *decimal.Decimal {
+	d := decimal.RequireFromString(s)
+	return &d
+}
+
+func requireDecimalPtr(t *testing.T, expected, actual *decimal.Decimal, name string) {
+	t.Helper()
+	if expected == nil {
+		require.Nil(t, actual, name)
+		return
+	}
+	require.NotNil(t, actual, name)
+	require.True(t, expected.Equal(*actual), "%s: %s != %s", name, expected, actual)
+}
@@ -1,40 +1,300 @@
 package domain
 
 import (
+	"fmt"
 	"log/slog"
 	"market-info-storage/internal/utils/slogutils"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
 type OrderBookService struct {
-	orderBookStorage OrderBookStorage
+	orderBookStorage         OrderBookStorage
+	orderBookSnapshotStorage OrderBookSnapshotStorage
+	validator                *OrderBookValidator
+	hub                      *OrderBookHub
+	symbols                  *SymbolNormalizer
+	instruments              InstrumentLookup
+	exchanges                ExchangeLookup
 }
 
 type OrderBookStorage interface {
-	SaveOrderBook(exchangeName, pair string, asks, bids []DepthOrder) error
-	GetOrderBook(exchangeName, pair string) (bids, asks []DepthOrder, err error)
+	SaveOrderBook(exchangeName, pair string, orderBook *OrderBook) error
+	// SaveOrderBookIfMatch replaces the stored order book only if its version is one of versions,
+	// any stored book is replaced if versions is empty. OrderBookVersionMismatch is returned otherwise.
+	SaveOrderBookIfMatch(exchangeName, pair string, orderBook *OrderBook, versions []int64) error
+	// SaveOrderBooks saves all books of a batch or none of them.
+	SaveOrderBooks(items []OrderBookBatchItem) error
+	// GetOrderBook returns the stored order book with levels limited by opts.
+	GetOrderBook(exchangeName, pair string, opts OrderBookReadOptions) (*OrderBook, error)
+	// GetOrderBooks returns order books by pair and exchange name with levels limited by opts.
+	// Books of all pairs or exchanges are returned if pairs or exchangeNames are empty.
+	GetOrderBooks(pairs, exchangeNames []string, opts OrderBookReadOptions) (map[string]map[string]*OrderBook, error)
+	// UpdateOrderBook locks the stored order book, passes it to update and
+	// saves the result unless update returns an error.
+	UpdateOrderBook(exchangeName, pair string, update func(orderBook *OrderBook) error) error
+	// DeleteOrderBook returns OrderBookNotFound if there is no book to delete.
+	DeleteOrderBook(exchangeName, pair string) error
+	// DeleteExchangeOrderBooks returns the number of deleted books.
+	DeleteExchangeOrderBooks(exchangeName string) (int64, error)
 }
 
-func NewOrderBookService(orderBookStorage OrderBookStorage) *OrderBookService {
+type OrderBookSnapshotStorage interface {
+	SaveOrderBookSnapshot(exchangeName, pair string, snapshot *OrderBookSnapshot) error
+	// SaveOrderBookSnapshots saves books of items as snapshots taken at their update time,
+	// kept for the retention of their exchange in retentions, or forever if it is missing.
+	SaveOrderBookSnapshots(items []OrderBookBatchItem, retentions map[string]time.Duration) error
+	GetOrderBookSnapshot(exchangeName, pair string, at time.Time) (*OrderBookSnapshot, error)
+	GetOrderBookSnapshotTimes(exchangeName, pair string, from, to time.Time, limit int) ([]time.Time, error)
+}
+
+func NewOrderBookService(
+	orderBookStorage OrderBookStorage,
+	orderBookSnapshotStorage OrderBookSnapshotStorage,
+	validator *OrderBookValidator,
+	hub *OrderBookHub,
+	symbols *SymbolNormalizer,
+	instruments InstrumentLookup,
+	exchanges ExchangeLookup,
+) *OrderBookService {
 	return &OrderBookService{
-		orderBookStorage: orderBookStorage,
+		orderBookStorage:         orderBookStorage,
+		orderBookSnapshotStorage: orderBookSnapshotStorage,
+		validator:                validator,
+		hub:                      hub,
+		symbols:                  symbols,
+		instruments:              instruments,
+		exchanges:                exchanges,
 	}
 }
 
+// SaveOrderBook treats the first half of orderBook as bids and the second half as asks.
 func (s *OrderBookService) SaveOrderBook(exchangeName, pair string, orderBook []DepthOrder) error {
-	bids := orderBook[:int(len(orderBook)/2)]
-	asks := orderBook[int(len(orderBook)/2):]
-	err := s.orderBookStorage.SaveOrderBook(exchangeName, pair, bids, asks)
+	return s.SaveOrderBookSides(exchangeName, pair, &OrderBook{
+		Bids: orderBook[:int(len(orderBook)/2)],
+		Asks: orderBook[int(len(orderBook)/2):],
+	})
+}
+
+func (s *OrderBookService) SaveOrderBookSides(exchangeName, pair string, orderBook *OrderBook) error {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	return s.saveOrderBookSides(exchangeName, pair, orderBook, func() error {
+		return s.orderBookStorage.SaveOrderBook(exchangeName, pair, orderBook)
+	})
+}
+
+// SaveOrderBookSidesIfMatch saves the order book only if the stored book has one of versions,
+// with empty versions any stored book is replaced. OrderBookVersionMismatch is returned otherwise.
+func (s *OrderBookService) SaveOrderBookSidesIfMatch(exchangeName, pair string, orderBook *OrderBook, versions []int64) error {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	return s.saveOrderBookSides(exchangeName, pair, orderBook, func() error {
+		return s.orderBookStorage.SaveOrderBookIfMatch(exchangeName, pair, orderBook, versions)
+	})
+}
+
+func (s *OrderBookService) saveOrderBookSides(exchangeName, pair string, orderBook *OrderBook, save func() error) error {
+	err := checkExchangeWritable(s.exchanges, exchangeName)
+	if err != nil {
+		return err
+	}
+	err = s.validator.Validate(exchangeName, pair, orderBook)
+	if err != nil {
+		return err
+	}
+	if orderBook.Checksum != nil {
+		err = VerifyOrderBookChecksum(exchangeName, orderBook, orderBook.Checksum)
+		if err != nil {
+			return err
+		}
+	}
+	// the checksum is computed over levels in the order they were received
+	orderBook.SortLevels()
+	orderBook.UpdatedAt = time.Now()
+	orderBook.Stale = false
+
+	err = save()
+	if _, ok := err.(OrderBookVersionMismatch); ok {
+		return err
+	}
 	if err != nil {
 		err = errors.Wrap(err, "save order book")
 		slog.Error("", slogutils.ErrorAttr(err))
+		return err
+	}
+
+	s.saveOrderBookSnapshot(exchangeName, pair, orderBook)
+	s.hub.PublishOrderBook(exchangeName, pair, orderBook)
+	return nil
+}
+
+// SaveOrderBooks validates every book of the batch and saves the valid ones in one transaction.
+// Rejected books are reported as failures, in strict mode any failure rejects the whole batch.
+func (s *OrderBookService) SaveOrderBooks(items []OrderBookBatchItem, strict bool) ([]OrderBookBatchFailure, error) {
+	var failures []OrderBookBatchFailure
+	validItems := make([]OrderBookBatchItem, 0, len(items))
+	for i, item := range items {
+		item.Pair = s.symbols.NormalizePair(item.ExchangeName, item.Pair)
+		err := checkExchangeWritable(s.exchanges, item.ExchangeName)
+		if err == nil {
+			err = s.validator.Validate(item.ExchangeName, item.Pair, item.OrderBook)
+		}
+		if err == nil && item.OrderBook.Checksum != nil {
+			err = VerifyOrderBookChecksum(item.ExchangeName, item.OrderBook, item.OrderBook.Checksum)
+		}
+		if err != nil {
+			failure := OrderBookBatchFailure{
+				Index:        i,
+				ExchangeName: item.ExchangeName,
+				Pair:         item.Pair,
+				Error:        err.Error(),
+			}
+			if err, ok := err.(OrderBookInvalid); ok {
+				failure.Violations = err.Violations
+			}
+			failures = append(failures, failure)
+			continue
+		}
+		validItems = append(validItems, item)
+	}
+	if len(validItems) == 0 || strict && len(failures) > 0 {
+		return failures, nil
+	}
+
+	updatedAt := time.Now()
+	for _, item := range validItems {
+		item.OrderBook.SortLevels()
+		item.OrderBook.UpdatedAt = updatedAt
+		item.OrderBook.Stale = false
+	}
+	err := s.orderBookStorage.SaveOrderBooks(validItems)
+	if err != nil {
+		err = errors.Wrap(err, "save order books")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+
+	retentions := make(map[string]time.Duration)
+	for _, item := range validItems {
+		retentions[item.ExchangeName] = s.snapshotRetention(item.ExchangeName)
+	}
+	err = s.orderBookSnapshotStorage.SaveOrderBookSnapshots(validItems, retentions)
+	if err != nil {
+		err = errors.Wrap(err, "save order book snapshots")
+		slog.Error("", slogutils.ErrorAttr(err))
+	}
+	for _, item := range validItems {
+		s.hub.PublishOrderBook(item.ExchangeName, item.Pair, item.OrderBook)
+	}
+	return failures, nil
+}
+
+// ApplyOrderBookDelta applies delta to the stored order book. Gaps and out of order
+// deltas make the book require a new snapshot, which is reported with OrderBookResyncRequired.
+func (s *OrderBookService) ApplyOrderBookDelta(exchangeName, pair string, delta *OrderBookDelta) error {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	err := checkExchangeWritable(s.exchanges, exchangeName)
+	if err != nil {
+		return err
+	}
+	err = s.validator.ValidateDelta(exchangeName, pair, delta)
+	if err != nil {
+		return err
+	}
+
+	var applyErr error
+	var updatedOrderBook *OrderBook
+	err = s.orderBookStorage.UpdateOrderBook(exchangeName, pair, func(orderBook *OrderBook) error {
+		updatedOrderBook = orderBook
+		resyncRequired := orderBook.ResyncRequired
+		applyErr = orderBook.ApplyDelta(delta)
+		switch applyErr.(type) {
+		case nil:
+			orderBook.UpdatedAt = time.Now()
+			orderBook.Stale = false
+			err := s.validator.Validate(exchangeName, pair, orderBook)
+			if err == nil && delta.Checksum != nil {
+				err = VerifyOrderBookChecksum(exchangeName, orderBook, delta.Checksum)
+			}
+			return err
+		case OrderBookResyncRequired:
+			if resyncRequired {
+				// nothing changed, skip the write so the version and ETag stay the same
+				return applyErr
+			}
+			// keep the resync flag set by ApplyDelta
+			return nil
+		default:
+			return applyErr
+		}
+	})
+	switch err.(type) {
+	case nil:
+		if applyErr == nil {
+			s.saveOrderBookSnapshot(exchangeName, pair, updatedOrderBook)
+			s.hub.PublishOrderBook(exchangeName, pair, updatedOrderBook)
+		}
+		return applyErr
+	case OrderBookNotFound, OrderBookInvalid, OrderBookResyncRequired, OrderBookChecksumMismatch, ChecksumAlgorithmUnknown:
+		return err
+	default:
+		err = errors.Wrap(err, "apply order book delta")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return err
+	}
+}
+
+// DeleteOrderBook deletes the current order book, the snapshot history is kept.
+func (s *OrderBookService) DeleteOrderBook(exchangeName, pair string) error {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	err := s.orderBookStorage.DeleteOrderBook(exchangeName, pair)
+	switch err.(type) {
+	case nil, OrderBookNotFound:
+		return err
+	default:
+		err = errors.Wrap(err, "delete order book")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return err
 	}
-	return err
 }
 
+// DeleteExchangeOrderBooks deletes current order books of all pairs of the exchange
+// and returns their number, the snapshot history is kept.
+func (s *OrderBookService) DeleteExchangeOrderBooks(exchangeName string) (int64, error) {
+	count, err := s.orderBookStorage.DeleteExchangeOrderBooks(exchangeName)
+	if err != nil {
+		err = errors.Wrap(err, "delete exchange order books")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return 0, err
+	}
+	if count == 0 {
+		return 0, OrderBookNotFound{Message: "no order books found for the exchange"}
+	}
+
+	return count, nil
+}
+
+// GetOrderBook returns bids followed by asks.
 func (s *OrderBookService) GetOrderBook(exchangeName, pair string) (orderBook []DepthOrder, err error) {
-	bids, asks, err := s.orderBookStorage.GetOrderBook(exchangeName, pair)
+	sides, err := s.GetOrderBookSides(exchangeName, pair, OrderBookReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	orderBook = append(sides.Bids, sides.Asks...)
+	return orderBook, nil
+}
+
+// GetOrderBookSides returns the order book shaped by opts. Depth and side limits
+// are pushed down to the storage unless levels have to be grouped first.
+// A book updated longer than opts.MaxAge ago is reported with OrderBookStale.
+func (s *OrderBookService) GetOrderBookSides(exchangeName, pair string, opts OrderBookReadOptions) (*OrderBook, error) {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	storageOpts := opts
+	if opts.Group.IsPositive() {
+		storageOpts.Depth = 0
+	}
+	orderBook, err := s.orderBookStorage.GetOrderBook(exchangeName, pair, storageOpts)
 	switch err.(type) {
 	case nil:
 	case OrderBookNotFound:
@@ -42,8 +302,156 @@ func (s *OrderBookService) GetOrderBook(exchangeName, pair string) (orderBook []
 	default:
 		err = errors.Wrap(err, "get order book")
 		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+	if opts.MaxAge > 0 {
+		age := time.Since(orderBook.UpdatedAt)
+		if age > opts.MaxAge {
+			return nil, OrderBookStale{Message: fmt.Sprintf(
+				"order book was updated %s ago, max age is %s", age.Truncate(time.Millisecond), opts.MaxAge)}
+		}
+	}
+
+	orderBook.ApplyReadOptions(opts)
+	return orderBook, nil
+}
+
+// GetOrderBookAt returns the latest order book snapshot saved at or before at.
+func (s *OrderBookService) GetOrderBookAt(exchangeName, pair string, at time.Time, opts OrderBookReadOptions) (*OrderBookSnapshot, error) {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	snapshot, err := s.orderBookSnapshotStorage.GetOrderBookSnapshot(exchangeName, pair, at)
+	switch err.(type) {
+	case nil:
+	case OrderBookNotFound:
+		return nil, err
+	default:
+		err = errors.Wrap(err, "get order book snapshot")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+
+	snapshot.OrderBook.ApplyReadOptions(opts)
+	return snapshot, nil
+}
+
+// GetInstrument returns the instrument of the pair to format order book levels with.
+func (s *OrderBookService) GetInstrument(exchangeName, pair string) (*Instrument, error) {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	instrument, ok := s.instruments.LookupInstrument(exchangeName, pair)
+	if !ok {
+		return nil, InstrumentNotFound{Message: "instrument not found"}
+	}
+	return &instrument, nil
+}
+
+func (s *OrderBookService) GetOrderBookSnapshotTimes(exchangeName, pair string, from, to time.Time, limit int) ([]time.Time, error) {
+	pair = s.symbols.NormalizePair(exchangeName, pair)
+	snapshotTimes, err := s.orderBookSnapshotStorage.GetOrderBookSnapshotTimes(exchangeName, pair, from, to, limit)
+	if err != nil {
+		err = errors.Wrap(err, "get order book snapshot times")
+		slog.Error("", slogutils.ErrorAttr(err))
+	}
+	return snapshotTimes, err
+}
+
+// SubscribeOrderBooks subscribes to updates of the books with keys and returns the current
+// state of those of them that exist. Updates published before the state was read
+// may still be delivered and have to be skipped by their Version.
+func (s *OrderBookService) SubscribeOrderBooks(keys []OrderBookKey, conflate bool) (*OrderBookSubscription, []OrderBookUpdate, error) {
+	normalizedKeys := make([]OrderBookKey, 0, len(keys))
+	for _, key := range keys {
+		key.Pair = s.symbols.NormalizePair(key.ExchangeName, key.Pair)
+		normalizedKeys = append(normalizedKeys, key)
+	}
+	keys = normalizedKeys
+	sub := s.hub.Subscribe(keys, conflate)
+
+	snapshots := make([]OrderBookUpdate, 0, len(keys))
+	for _, key := range keys {
+		orderBook, err := s.orderBookStorage.GetOrderBook(key.ExchangeName, key.Pair, OrderBookReadOptions{})
+		switch err.(type) {
+		case nil:
+			snapshots = append(snapshots, OrderBookUpdate{
+				ExchangeName: key.ExchangeName,
+				Pair:         key.Pair,
+				OrderBook:    orderBook,
+			})
+		case OrderBookNotFound:
+		default:
+			sub.Close()
+			err = errors.Wrap(err, "get order book")
+			slog.Error("", slogutils.ErrorAttr(err))
+			return nil, nil, err
+		}
+	}
+
+	return sub, snapshots, nil
+}
+
+// saveOrderBookSnapshot appends the order book to the snapshot history.
+// The current order book is already saved at this point, so a failure is only logged.
+func (s *OrderBookService) saveOrderBookSnapshot(exchangeName, pair string, orderBook *OrderBook) {
+	err := s.orderBookSnapshotStorage.SaveOrderBookSnapshot(exchangeName, pair, &OrderBookSnapshot{
+		Time:      orderBook.UpdatedAt,
+		OrderBook: orderBook,
+		Retention: s.snapshotRetention(exchangeName),
+	})
+	if err != nil {
+		err = errors.Wrap(err, "save order book snapshot")
+		slog.Error("", slogutils.ErrorAttr(err))
+	}
+}
+
+// snapshotRetention is the snapshot retention of the registered exchange, 0 keeps snapshots forever.
+func (s *OrderBookService) snapshotRetention(exchangeName string) time.Duration {
+	exchange, ok := s.exchanges.LookupExchange(exchangeName)
+	if !ok {
+		return 0
+	}
+	return time.Duration(exchange.SnapshotRetentionHours) * time.Hour
+}
+
+// GetOrderBookStats computes top of book metrics of the stored order book,
+// imbalance is computed over the given number of top levels of each side.
+func (s *OrderBookService) GetOrderBookStats(exchangeName, pair string, levels int) (*OrderBookStats, error) {
+	orderBook, err := s.GetOrderBookSides(exchangeName, pair, OrderBookReadOptions{Depth: levels})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := orderBook.Stats(levels)
+	return &stats, nil
+}
+
+// EstimateMarketImpact walks the stored order book with a market order
+// sized either in base quantity or in quote notional.
+func (s *OrderBookService) EstimateMarketImpact(exchangeName, pair string, side OrderSide, baseQty, quoteQty float64) (*MarketImpact, error) {
+	orderBook, err := s.GetOrderBookSides(exchangeName, pair, OrderBookReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	impact := orderBook.EstimateMarketImpact(side, baseQty, quoteQty)
+	return &impact, nil
+}
+
+// GetConsolidatedOrderBook merges stored order books of the pair from exchangeNames,
+// or from all exchanges if exchangeNames is empty.
+func (s *OrderBookService) GetConsolidatedOrderBook(pair string, exchangeNames []string, opts OrderBookReadOptions) (*ConsolidatedOrderBook, error) {
+	pair = s.symbols.NormalizePair("", pair)
+	storageOpts := opts
+	if opts.Group.IsPositive() {
+		storageOpts.Depth = 0
+	}
+	orderBooks, err := s.orderBookStorage.GetOrderBooks([]string{pair}, exchangeNames, storageOpts)
+	if err != nil {
+		err = errors.Wrap(err, "get order books")
+		slog.Error("", slogutils.ErrorAttr(err))
+		return nil, err
+	}
+	if len(orderBooks[pair]) == 0 {
+		return nil, OrderBookNotFound{Message: "no order books found for the pair"}
 	}
 
-	orderBook = append(bids, asks...)
-	return orderBook, err
+	return ConsolidateOrderBooks(orderBooks[pair], opts), nil
 }
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrderBookStorage keeps books in memory, UpdateOrderBook commits only if update succeeds.
+type fakeOrderBookStorage struct {
+	OrderBookStorage
+
+	orderBooks map[OrderBookKey]*OrderBook
+	writes     int
+	version    int64
+}
+
+func (s *fakeOrderBookStorage) SaveOrderBook(exchangeName, pair string, orderBook *OrderBook) error {
+	s.writes++
+	s.version++
+	orderBook.Version = s.version
+	stored := *orderBook
+	stored.Bids = append([]DepthOrder(nil), orderBook.Bids...)
+	stored.Asks = append([]DepthOrder(nil), orderBook.Asks...)
+	s.orderBooks[OrderBookKey{ExchangeName: exchangeName, Pair: pair}] = &stored
+	return nil
+}
+
+func (s *fakeOrderBookStorage) UpdateOrderBook(exchangeName, pair string, update func(orderBook *OrderBook) error) error {
+	stored, ok := s.orderBooks[OrderBookKey{ExchangeName: exchangeName, Pair: pair}]
+	if !ok {
+		return OrderBookNotFound{Message: "order book not found"}
+	}
+	orderBook := *stored
+	orderBook.Bids = append([]DepthOrder(nil), stored.Bids...)
+	orderBook.Asks = append([]DepthOrder(nil), stored.Asks...)
+	err := update(&orderBook)
+	if err != nil {
+		return err
+	}
+	s.writes++
+	s.version++
+	orderBook.Version = s.version
+	s.orderBooks[OrderBookKey{ExchangeName: exchangeName, Pair: pair}] = &orderBook
+	return nil
+}
+
+type fakeSnapshotStorage struct {
+	OrderBookSnapshotStorage
+}
+
+func (s fakeSnapshotStorage) SaveOrderBookSnapshot(exchangeName, pair string, snapshot *OrderBookSnapshot) error {
+	return nil
+}
+
+type fakeExchangeLookup map[string]Exchange
+
+func (l fakeExchangeLookup) LookupExchange(exchangeName string) (Exchange, bool) {
+	exchange, ok := l[exchangeName]
+	return exchange, ok
+}
+
+func newTestOrderBookService(storage OrderBookStorage) *OrderBookService {
+	return newTestOrderBookServiceWithMode(storage, ValidationModeStrict)
+}
+
+func newTestOrderBookServiceWithMode(storage OrderBookStorage, mode ValidationMode) *OrderBookService {
+	exchanges := fakeExchangeLookup{"bybit": {Name: "bybit", Enabled: true}}
+	return NewOrderBookService(
+		storage,
+		fakeSnapshotStorage{},
+		NewOrderBookValidator(mode, nil, nil, exchanges),
+		NewOrderBookHub(16),
+		NewSymbolNormalizer([]string{"USDT"}, nil, nil),
+		nil,
+		exchanges,
+	)
+}
+
+func level(price, qty string) DepthOrder {
+	return DepthOrder{Price: decimal.RequireFromString(price), BaseQty: decimal.RequireFromString(qty)}
+}
+
+func TestApplyOrderBookDeltaResync(t *testing.T) {
+	sequence := int64(10)
+	storage := &fakeOrderBookStorage{orderBooks: map[OrderBookKey]*OrderBook{
+		{ExchangeName: "bybit", Pair: "BTC_USDT"}: {
+			Bids:     []DepthOrder{level("100", "1")},
+			Asks:     []DepthOrder{level("101", "1")},
+			Sequence: &sequence,
+			Version:  1,
+		},
+	}}
+	service := newTestOrderBookService(storage)
+	key := OrderBookKey{ExchangeName: "bybit", Pair: "BTC_USDT"}
+
+	// the gap sets the resync flag once
+	err := service.ApplyOrderBookDelta("bybit", "BTC_USDT", &OrderBookDelta{Sequence: 12})
+	require.IsType(t, OrderBookResyncRequired{}, err)
+	require.Equal(t, 1, storage.writes)
+	require.True(t, storage.orderBooks[key].ResyncRequired)
+	version := storage.orderBooks[key].Version
+
+	// further deltas are rejected without writing the book again
+	for _, seq := range []int64{13, 14, 11} {
+		err = service.ApplyOrderBookDelta("bybit", "BTC_USDT", &OrderBookDelta{Sequence: seq})
+		require.IsType(t, OrderBookResyncRequired{}, err)
+	}
+	require.Equal(t, 1, storage.writes)
+	require.Equal(t, version, storage.orderBooks[key].Version)
+}
+
+func TestSaveOrderBookSidesSortsLevels(t *testing.T) {
+	storage := &fakeOrderBookStorage{orderBooks: map[OrderBookKey]*OrderBook{}}
+	service := newTestOrderBookServiceWithMode(storage, ValidationModeLenient)
+	key := OrderBookKey{ExchangeName: "bybit", Pair: "BTC_USDT"}
+
+	// the lenient mode accepts unsorted levels
+	err := service.SaveOrderBookSides("bybit", "BTC_USDT", &OrderBook{
+		Bids:     []DepthOrder{level("98", "1"), level("100", "1"), level("99", "1")},
+		Asks:     []DepthOrder{level("103", "1"), level("101", "1"), level("102", "1")},
+		Sequence: sequencePtr(1),
+	})
+	require.NoError(t, err)
+	requireLevels(t, []DepthOrder{level("100", "1"), level("99", "1"), level("98", "1")}, storage.orderBooks[key].Bids)
+	requireLevels(t, []DepthOrder{level("101", "1"), level("102", "1"), level("103", "1")}, storage.orderBooks[key].Asks)
+
+	err = service.ApplyOrderBookDelta("bybit", "BTC_USDT", &OrderBookDelta{
+		Sequence: 2,
+		Bids:     []DepthOrder{level("100", "0"), level("98.5", "2")},
+		Asks:     []DepthOrder{level("101", "3")},
+	})
+	require.NoError(t, err)
+	requireLevels(t, []DepthOrder{level("99", "1"), level("98.5", "2"), level("98", "1")}, storage.orderBooks[key].Bids)
+	requireLevels(t, []DepthOrder{level("101", "3"), level("102", "1"), level("103", "1")}, storage.orderBooks[key].Asks)
+}
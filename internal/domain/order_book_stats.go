@@ -0,0 +1,63 @@
+package domain
+
+// OrderBookStats are top of book metrics. Metrics that need both sides
+// are nil if one of the sides is empty. Derived metrics are computed in float64.
+type OrderBookStats struct {
+	BestBid *DepthOrder `json:"bestBid"`
+	BestAsk *DepthOrder `json:"bestAsk"`
+	Mid     *float64    `json:"mid"`
+	Spread  *float64    `json:"spread"`
+	// SpreadBps is the spread relative to the mid price in basis points.
+	SpreadBps *float64 `json:"spreadBps"`
+	// Microprice is the mid price weighted by the opposite side top level quantities.
+	Microprice *float64 `json:"microprice"`
+	// Imbalance is (bidQty - askQty) / (bidQty + askQty) over the top ImbalanceLevels
+	// levels of each side, ranging from -1 (only asks) to 1 (only bids).
+	Imbalance       *float64 `json:"imbalance"`
+	ImbalanceLevels int      `json:"imbalanceLevels"`
+}
+
+const bps = 10000
+
+// Stats computes top of book metrics, imbalance is computed over the given number of top levels.
+func (ob *OrderBook) Stats(levels int) OrderBookStats {
+	stats := OrderBookStats{ImbalanceLevels: levels}
+	if len(ob.Bids) > 0 {
+		stats.BestBid = &ob.Bids[0]
+	}
+	if len(ob.Asks) > 0 {
+		stats.BestAsk = &ob.Asks[0]
+	}
+
+	bidQty := sumBaseQty(ob.Bids[:min(levels, len(ob.Bids))])
+	askQty := sumBaseQty(ob.Asks[:min(levels, len(ob.Asks))])
+	if bidQty+askQty > 0 {
+		imbalance := (bidQty - askQty) / (bidQty + askQty)
+		stats.Imbalance = &imbalance
+	}
+
+	if stats.BestBid == nil || stats.BestAsk == nil {
+		return stats
+	}
+	bid, ask := *stats.BestBid, *stats.BestAsk
+	bidPrice, bidQty := bid.Price.InexactFloat64(), bid.BaseQty.InexactFloat64()
+	askPrice, askQty := ask.Price.InexactFloat64(), ask.BaseQty.InexactFloat64()
+	mid := (bidPrice + askPrice) / 2
+	spread := askPrice - bidPrice
+	spreadBps := spread / mid * bps
+	microprice := (bidPrice*askQty + askPrice*bidQty) / (bidQty + askQty)
+	stats.Mid = &mid
+	stats.Spread = &spread
+	stats.SpreadBps = &spreadBps
+	stats.Microprice = &microprice
+
+	return stats
+}
+
+func sumBaseQty(depthOrders []DepthOrder) float64 {
+	var sum float64
+	for _, depthOrder := range depthOrders {
+		sum += depthOrder.BaseQty.InexactFloat64()
+	}
+	return sum
+}
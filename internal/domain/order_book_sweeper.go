@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+	"market-info-storage/internal/utils/slogutils"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// OrderBookSweeper periodically flags order books that stopped updating
+// as stale and deletes them once they are too old to be useful.
+type OrderBookSweeper struct {
+	storage     OrderBookSweepStorage
+	interval    time.Duration
+	staleAfter  time.Duration
+	expireAfter time.Duration
+}
+
+type OrderBookSweepStorage interface {
+	// MarkOrderBooksStale flags books updated before updatedBefore and returns their number.
+	MarkOrderBooksStale(updatedBefore time.Time) (int64, error)
+	// DeleteOrderBooksUpdatedBefore deletes books updated before updatedBefore and returns their number.
+	DeleteOrderBooksUpdatedBefore(updatedBefore time.Time) (int64, error)
+}
+
+// NewOrderBookSweeper creates a sweeper running every interval, an interval of 0 disables it.
+// Books are flagged after staleAfter and deleted after expireAfter, 0 disables the respective step.
+func NewOrderBookSweeper(storage OrderBookSweepStorage, interval, staleAfter, expireAfter time.Duration) *OrderBookSweeper {
+	return &OrderBookSweeper{
+		storage:     storage,
+		interval:    interval,
+		staleAfter:  staleAfter,
+		expireAfter: expireAfter,
+	}
+}
+
+// Run sweeps order books until ctx is done, it returns at once if the sweeper is disabled.
+func (s *OrderBookSweeper) Run(ctx context.Context) {
+	if s.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Sweep()
+		}
+	}
+}
+
+func (s *OrderBookSweeper) Sweep() {
+	now := time.Now()
+	if s.staleAfter > 0 {
+		count, err := s.storage.MarkOrderBooksStale(now.Add(-s.staleAfter))
+		if err != nil {
+			err = errors.Wrap(err, "mark order books stale")
+			slog.Error("", slogutils.ErrorAttr(err))
+		} else if count > 0 {
+			slog.Info("marked order books stale", slog.Int64("count", count))
+		}
+	}
+	if s.expireAfter > 0 {
+		count, err := s.storage.DeleteOrderBooksUpdatedBefore(now.Add(-s.expireAfter))
+		if err != nil {
+			err = errors.Wrap(err, "delete expired order books")
+			slog.Error("", slogutils.ErrorAttr(err))
+		} else if count > 0 {
+			slog.Info("deleted expired order books", slog.Int64("count", count))
+		}
+	}
+}
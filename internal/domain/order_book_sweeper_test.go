@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOrderBookSweeperRunDisabled(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		sweeper := NewOrderBookSweeper(nil, interval, time.Minute, 0)
+		requireReturns(t, func() { sweeper.Run(context.Background()) })
+	}
+}
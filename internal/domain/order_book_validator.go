@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type ValidationMode string
+
+const (
+	// ValidationModeStrict rejects an order book on any violation.
+	ValidationModeStrict ValidationMode = "strict"
+	// ValidationModeLenient rejects an order book only when some level has
+	// an unusable price or quantity; ordering, duplicate and crossed book
+	// violations are logged and the book is accepted, it is saved with sorted levels.
+	ValidationModeLenient ValidationMode = "lenient"
+)
+
+func ParseValidationMode(s string) (ValidationMode, error) {
+	switch mode := ValidationMode(s); mode {
+	case ValidationModeStrict, ValidationModeLenient:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown validation mode: %q", s)
+	}
+}
+
+const (
+	ReasonPriceNotPositive  = "price is not positive"
+	ReasonQtyNotPositive    = "base quantity is not positive"
+	ReasonQtyNegative       = "base quantity is negative"
+	ReasonDuplicatePrice    = "duplicate price level"
+	ReasonBidsNotDescending = "bids are not sorted by price in descending order"
+	ReasonAsksNotAscending  = "asks are not sorted by price in ascending order"
+	ReasonCrossedBook       = "best bid price is greater than or equal to best ask price"
+	ReasonPriceOffTick      = "price is not a multiple of the instrument's tick size"
+	ReasonQtyOffStep        = "base quantity is not a multiple of the instrument's quantity step"
+)
+
+type LevelViolation struct {
+	Side   Side   `json:"side"`
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+	// fatal violations reject the book in every validation mode
+	fatal bool
+}
+
+type OrderBookValidator struct {
+	defaultMode   ValidationMode
+	exchangeModes map[string]ValidationMode
+	// instruments reject off-tick prices and off-step quantities, nil disables the check
+	instruments InstrumentLookup
+	exchanges   ExchangeLookup
+}
+
+// NewOrderBookValidator creates a validator, levels of pairs with a registered instrument
+// are checked against it in every validation mode unless instruments is nil.
+// The validation mode of a registered exchange overrides exchangeModes.
+func NewOrderBookValidator(
+	defaultMode ValidationMode,
+	exchangeModes map[string]ValidationMode,
+	instruments InstrumentLookup,
+	exchanges ExchangeLookup,
+) *OrderBookValidator {
+	return &OrderBookValidator{
+		defaultMode:   defaultMode,
+		exchangeModes: exchangeModes,
+		instruments:   instruments,
+		exchanges:     exchanges,
+	}
+}
+
+func (v *OrderBookValidator) Mode(exchangeName string) ValidationMode {
+	if exchange, ok := v.exchanges.LookupExchange(exchangeName); ok && exchange.ValidationMode != "" {
+		return exchange.ValidationMode
+	}
+	if mode, ok := v.exchangeModes[exchangeName]; ok {
+		return mode
+	}
+	return v.defaultMode
+}
+
+// Validate returns OrderBookInvalid listing every offending level
+// if the order book must be rejected in the exchange's validation mode.
+func (v *OrderBookValidator) Validate(exchangeName, pair string, orderBook *OrderBook) error {
+	violations := findOrderBookViolations(orderBook)
+	violations = append(violations, v.findInstrumentViolations(exchangeName, pair, orderBook.Bids, orderBook.Asks)...)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	mode := v.Mode(exchangeName)
+	if mode == ValidationModeLenient {
+		rejected := false
+		for _, violation := range violations {
+			rejected = rejected || violation.fatal
+		}
+		if !rejected {
+			slog.Warn("accepted order book with violations",
+				slog.String("exchange", exchangeName),
+				slog.Int("violations", len(violations)))
+			return nil
+		}
+	}
+
+	return OrderBookInvalid{Violations: violations}
+}
+
+func findOrderBookViolations(orderBook *OrderBook) []LevelViolation {
+	var violations []LevelViolation
+	violations = append(violations, findSideViolations(SideBid, orderBook.Bids)...)
+	violations = append(violations, findSideViolations(SideAsk, orderBook.Asks)...)
+
+	if len(orderBook.Bids) > 0 && len(orderBook.Asks) > 0 &&
+		orderBook.Bids[0].Price.GreaterThanOrEqual(orderBook.Asks[0].Price) {
+		violations = append(violations,
+			LevelViolation{Side: SideBid, Index: 0, Reason: ReasonCrossedBook},
+			LevelViolation{Side: SideAsk, Index: 0, Reason: ReasonCrossedBook})
+	}
+
+	return violations
+}
+
+func findSideViolations(side Side, depthOrders []DepthOrder) []LevelViolation {
+	var violations []LevelViolation
+	seenPrices := make(map[string]struct{}, len(depthOrders))
+	for i, depthOrder := range depthOrders {
+		violation := LevelViolation{Side: side, Index: i}
+		violations = append(violations, findLevelViolations(violation, depthOrder, false)...)
+
+		// prices are compared normalized as 0.5 and 0.50 are the same level
+		price := depthOrder.Price.String()
+		if _, ok := seenPrices[price]; ok {
+			violations = append(violations, withReason(violation, ReasonDuplicatePrice, false))
+		}
+		seenPrices[price] = struct{}{}
+
+		if i == 0 {
+			continue
+		}
+		prevPrice := depthOrders[i-1].Price
+		if side == SideBid && depthOrder.Price.GreaterThan(prevPrice) {
+			violations = append(violations, withReason(violation, ReasonBidsNotDescending, false))
+		}
+		if side == SideAsk && depthOrder.Price.LessThan(prevPrice) {
+			violations = append(violations, withReason(violation, ReasonAsksNotAscending, false))
+		}
+	}
+
+	return violations
+}
+
+// findLevelViolations checks price and quantity of a single level.
+// Zero quantity is allowed for delta levels, where it means removal.
+func findLevelViolations(violation LevelViolation, depthOrder DepthOrder, allowZeroQty bool) []LevelViolation {
+	var violations []LevelViolation
+	if !depthOrder.Price.IsPositive() {
+		violations = append(violations, withReason(violation, ReasonPriceNotPositive, true))
+	}
+	switch {
+	case allowZeroQty && depthOrder.BaseQty.IsNegative():
+		violations = append(violations, withReason(violation, ReasonQtyNegative, true))
+	case !allowZeroQty && !depthOrder.BaseQty.IsPositive():
+		violations = append(violations, withReason(violation, ReasonQtyNotPositive, true))
+	}
+	return violations
+}
+
+// ValidateDelta checks every delta level regardless of validation mode.
+func (v *OrderBookValidator) ValidateDelta(exchangeName, pair string, delta *OrderBookDelta) error {
+	var violations []LevelViolation
+	violations = append(violations, findDeltaSideViolations(SideBid, delta.Bids)...)
+	violations = append(violations, findDeltaSideViolations(SideAsk, delta.Asks)...)
+	violations = append(violations, v.findInstrumentViolations(exchangeName, pair, delta.Bids, delta.Asks)...)
+	if len(violations) > 0 {
+		return OrderBookInvalid{Violations: violations}
+	}
+
+	return nil
+}
+
+func findDeltaSideViolations(side Side, depthOrders []DepthOrder) []LevelViolation {
+	var violations []LevelViolation
+	seenPrices := make(map[string]struct{}, len(depthOrders))
+	for i, depthOrder := range depthOrders {
+		violation := LevelViolation{Side: side, Index: i}
+		violations = append(violations, findLevelViolations(violation, depthOrder, true)...)
+
+		price := depthOrder.Price.String()
+		if _, ok := seenPrices[price]; ok {
+			violations = append(violations, withReason(violation, ReasonDuplicatePrice, true))
+		}
+		seenPrices[price] = struct{}{}
+	}
+
+	return violations
+}
+
+// findInstrumentViolations checks levels against the pair's instrument, pairs
+// without a registered instrument are not checked.
+func (v *OrderBookValidator) findInstrumentViolations(exchangeName, pair string, bids, asks []DepthOrder) []LevelViolation {
+	if v.instruments == nil {
+		return nil
+	}
+	instrument, ok := v.instruments.LookupInstrument(exchangeName, pair)
+	if !ok {
+		return nil
+	}
+
+	var violations []LevelViolation
+	for _, side := range []struct {
+		side        Side
+		depthOrders []DepthOrder
+	}{{SideBid, bids}, {SideAsk, asks}} {
+		for i, depthOrder := range side.depthOrders {
+			violation := LevelViolation{Side: side.side, Index: i}
+			if !instrument.IsOnTick(depthOrder.Price) {
+				violations = append(violations, withReason(violation, ReasonPriceOffTick, true))
+			}
+			if !instrument.IsOnStep(depthOrder.BaseQty) {
+				violations = append(violations, withReason(violation, ReasonQtyOffStep, true))
+			}
+		}
+	}
+	return violations
+}
+
+func withReason(violation LevelViolation, reason string, fatal bool) LevelViolation {
+	violation.Reason = reason
+	violation.fatal = fatal
+	return violation
+}
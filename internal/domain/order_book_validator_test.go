@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInstrumentLookup map[OrderBookKey]Instrument
+
+func (l fakeInstrumentLookup) LookupInstrument(exchangeName, pair string) (Instrument, bool) {
+	instrument, ok := l[OrderBookKey{ExchangeName: exchangeName, Pair: pair}]
+	return instrument, ok
+}
+
+func newTestOrderBookValidator() *OrderBookValidator {
+	instruments := fakeInstrumentLookup{
+		{ExchangeName: "bybit", Pair: "ETH_USDT"}: {
+			TickSize: decimal.RequireFromString("0.5"),
+			QtyStep:  decimal.RequireFromString("0.1"),
+		},
+	}
+	return NewOrderBookValidator(
+		ValidationModeStrict,
+		map[string]ValidationMode{"okx": ValidationModeLenient},
+		instruments,
+		fakeExchangeLookup{"okx": {Name: "okx"}},
+	)
+}
+
+func TestOrderBookValidatorValidate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		exchange   string
+		pair       string
+		bids       []DepthOrder
+		asks       []DepthOrder
+		violations []LevelViolation
+		rejected   bool
+	}{
+		{
+			name:     "Valid",
+			exchange: "bybit",
+			pair:     "BTC_USDT",
+			bids:     []DepthOrder{level("100", "1"), level("99", "2")},
+			asks:     []DepthOrder{level("101", "1"), level("102", "2")},
+		},
+		{
+			name:     "EmptySides",
+			exchange: "bybit",
+			pair:     "BTC_USDT",
+		},
+		{
+			name:     "Crossed",
+			exchange: "bybit",
+			pair:     "BTC_USDT",
+			bids:     []DepthOrder{level("101", "1")},
+			asks:     []DepthOrder{level("100", "1")},
+			violations: []LevelViolation{
+				{Side: SideBid, Index: 0, Reason: ReasonCrossedBook},
+				{Side: SideAsk, Index: 0, Reason: ReasonCrossedBook},
+			},
+			rejected: true,
+		},
+		{
+			name:     "Locked",
+			exchange: "bybit",
+			pair:     "BTC_USDT",
+			bids:     []DepthOrder{level("100", "1")},
+			asks:     []DepthOrder{level("100.0", "1")},
+			violations: []LevelViolation{
+				{Side: SideBid, Index: 0, Reason: ReasonCrossedBook},
+				{Side: SideAsk, Index: 0, Reason: ReasonCrossedBook},
+			},
+			rejected: true,
+		},
+		{
+			name:     "DuplicatePrice",
+			exchange: "bybit",
+			pair:     "BTC_USDT",
+			bids:     []DepthOrder{level("100", "1"), level("100.00", "2")},
+			asks:     []DepthOrder{level("101", "1")},
+			violations: []LevelViolation{
+				{Side: SideBid, Index: 1, Reason: ReasonDuplicatePrice},
+			},
+			rejected: true,
+		},
+		{
+			name:     "Unsorted",
+			exchange: "bybit",
+			pair:     "BTC_USDT",
+			bids:     []DepthOrder{level("99", "1"), level("100", "1")},
+			asks:     []DepthOrder{level("102", "1"), level("101", "1")},
+			violations: []LevelViolation{
+				{Side: SideBid, Index: 1, Reason: ReasonBidsNotDescending},
+				{Side: SideAsk, Index: 1, Reason: ReasonAsksNotAscending},
+			},
+			rejected: true,
+		},
+		{
+			name:     "NotPositive",
+			exchange: "bybit",
+			pair:     "BTC_USDT",
+			bids:     []DepthOrder{level("0", "1")},
+			asks:     []DepthOrder{level("101", "-1")},
+			violations: []LevelViolation{
+				{Side: SideBid, Index: 0, Reason: ReasonPriceNotPositive},
+				{Side: SideAsk, Index: 0, Reason: ReasonQtyNotPositive},
+			},
+			rejected: true,
+		},
+		{
+			name:     "LenientAcceptsCrossed",
+			exchange: "okx",
+			pair:     "BTC_USDT",
+			bids:     []DepthOrder{level("101", "1"), level("101", "1")},
+			asks:     []DepthOrder{level("100", "1")},
+			violations: []LevelViolation{
+				{Side: SideBid, Index: 1, Reason: ReasonDuplicatePrice},
+				{Side: SideBid, Index: 0, Reason: ReasonCrossedBook},
+				{Side: SideAsk, Index: 0, Reason: ReasonCrossedBook},
+			},
+		},
+		{
+			name:     "LenientRejectsZeroQty",
+			exchange: "okx",
+			pair:     "BTC_USDT",
+			bids:     []DepthOrder{level("100", "0")},
+			violations: []LevelViolation{
+				{Side: SideBid, Index: 0, Reason: ReasonQtyNotPositive},
+			},
+			rejected: true,
+		},
+		{
+			name:     "OffTick",
+			exchange: "bybit",
+			pair:     "ETH_USDT",
+			bids:     []DepthOrder{level("100.5", "1.25")},
+			asks:     []DepthOrder{level("100.7", "1")},
+			violations: []LevelViolation{
+				{Side: SideBid, Index: 0, Reason: ReasonQtyOffStep},
+				{Side: SideAsk, Index: 0, Reason: ReasonPriceOffTick},
+			},
+			rejected: true,
+		},
+	}
+
+	validator := newTestOrderBookValidator()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			orderBook := &OrderBook{Bids: tc.bids, Asks: tc.asks}
+
+			violations := findOrderBookViolations(orderBook)
+			violations = append(violations, validator.findInstrumentViolations(tc.exchange, tc.pair, tc.bids, tc.asks)...)
+			for i := range violations {
+				violations[i].fatal = false
+			}
+			require.ElementsMatch(t, tc.violations, violations)
+
+			err := validator.Validate(tc.exchange, tc.pair, orderBook)
+			if tc.rejected {
+				require.IsType(t, OrderBookInvalid{}, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOrderBookValidatorValidateDelta(t *testing.T) {
+	testCases := []struct {
+		name     string
+		bids     []DepthOrder
+		asks     []DepthOrder
+		rejected bool
+	}{
+		{name: "ZeroQtyRemovesLevel", bids: []DepthOrder{level("100", "0")}},
+		{name: "Unsorted", bids: []DepthOrder{level("99", "1"), level("100", "1")}},
+		{name: "NegativeQty", asks: []DepthOrder{level("101", "-1")}, rejected: true},
+		{name: "ZeroPrice", asks: []DepthOrder{level("0", "1")}, rejected: true},
+		{name: "DuplicatePrice", bids: []DepthOrder{level("100", "1"), level("100", "0")}, rejected: true},
+	}
+
+	validator := newTestOrderBookValidator()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// deltas are checked the same way in the lenient mode
+			for _, exchange := range []string{"bybit", "okx"} {
+				err := validator.ValidateDelta(exchange, "BTC_USDT", &OrderBookDelta{Bids: tc.bids, Asks: tc.asks})
+				if tc.rejected {
+					require.IsType(t, OrderBookInvalid{}, err, exchange)
+				} else {
+					require.NoError(t, err, exchange)
+				}
+			}
+		})
+	}
+}
+
+// NaN and infinite levels never reach the validator, decoding rejects them.
+func TestDepthOrderRejectsNaN(t *testing.T) {
+	for _, body := range []string{
+		`{"price": "NaN", "baseQty": "1"}`,
+		`{"price": "100", "baseQty": "Infinity"}`,
+		`{"price": "-Inf", "baseQty": "1"}`,
+	} {
+		var depthOrder DepthOrder
+		err := json.Unmarshal([]byte(body), &depthOrder)
+		require.Error(t, err, body)
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"log/slog"
 	"market-info-storage/internal/utils/slogutils"
 
@@ -9,6 +10,11 @@ import (
 
 type OrderHistoryService struct {
 	orderHistoryStorage OrderHistoryStorage
+	eventLog            *EventLog
+	symbols             *SymbolNormalizer
+	// instruments reject orders breaking the pair's trading rules, nil disables the check
+	instruments InstrumentLookup
+	exchanges   ExchangeLookup
 }
 
 type OrderHistoryStorage interface {
@@ -16,26 +22,74 @@ type OrderHistoryStorage interface {
 	GetHistoryOrdersByClient(client *Client) ([]HistoryOrder, error)
 }
 
-func NewOrderHistoryService(orderHistoryStorage OrderHistoryStorage) *OrderHistoryService {
+func NewOrderHistoryService(
+	orderHistoryStorage OrderHistoryStorage,
+	eventLog *EventLog,
+	symbols *SymbolNormalizer,
+	instruments InstrumentLookup,
+	exchanges ExchangeLookup,
+) *OrderHistoryService {
 	return &OrderHistoryService{
 		orderHistoryStorage: orderHistoryStorage,
+		eventLog:            eventLog,
+		symbols:             symbols,
+		instruments:         instruments,
+		exchanges:           exchanges,
 	}
 }
 
+// SaveHistoryOrder returns HistoryOrderInvalid for an order with an off-tick price, an off-step
+// quantity or a notional below the minimum of the pair's instrument. Orders of exchanges that are not
+// registered or disabled are rejected with ExchangeNotFound and ExchangeDisabled.
 func (s *OrderHistoryService) SaveHistoryOrder(order *HistoryOrder) error {
-	err := s.orderHistoryStorage.SaveHistoryOrder(order)
+	order.Pair = s.symbols.NormalizePair(order.ExchangeName, order.Pair)
+	err := checkExchangeWritable(s.exchanges, order.ExchangeName)
+	if err != nil {
+		return err
+	}
+	err = s.validateHistoryOrder(order)
+	if err != nil {
+		return err
+	}
+
+	err = s.orderHistoryStorage.SaveHistoryOrder(order)
 	if err != nil {
 		err = errors.Wrap(err, "save order")
 		slog.Error("", slogutils.ErrorAttr(err))
+		return err
 	}
-	return err
+
+	s.eventLog.PublishHistoryOrder(order)
+	return nil
 }
 
 func (s *OrderHistoryService) GetHistoryOrdersByClient(client *Client) ([]HistoryOrder, error) {
-	orderHistory, err := s.orderHistoryStorage.GetHistoryOrdersByClient(client)
+	normalizedClient := *client
+	normalizedClient.Pair = s.symbols.NormalizePair(client.ExchangeName, client.Pair)
+	orderHistory, err := s.orderHistoryStorage.GetHistoryOrdersByClient(&normalizedClient)
 	if err != nil {
 		err = errors.Wrap(err, "get order history")
 		slog.Error("", slogutils.ErrorAttr(err))
 	}
 	return orderHistory, err
 }
+
+func (s *OrderHistoryService) validateHistoryOrder(order *HistoryOrder) error {
+	if s.instruments == nil {
+		return nil
+	}
+	instrument, ok := s.instruments.LookupInstrument(order.ExchangeName, order.Pair)
+	if !ok {
+		return nil
+	}
+
+	switch {
+	case !instrument.IsOnTick(order.Price):
+		return HistoryOrderInvalid{Message: fmt.Sprintf("price %s is not a multiple of tick size %s", order.Price, instrument.TickSize)}
+	case !instrument.IsOnStep(order.BaseQty):
+		return HistoryOrderInvalid{Message: fmt.Sprintf("base quantity %s is not a multiple of quantity step %s", order.BaseQty, instrument.QtyStep)}
+	case order.Price.Mul(order.BaseQty).LessThan(instrument.MinNotional):
+		return HistoryOrderInvalid{Message: fmt.Sprintf("notional %s is below minimum notional %s", order.Price.Mul(order.BaseQty), instrument.MinNotional)}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "strings"
+
+// pairSeparators are the separators exchanges put between base and quote assets.
+const pairSeparators = "_-/:"
+
+// SplitPair splits a pair such as BTC_USDT or BTC/USDT into base and quote assets.
+// ok is false for pairs without a separator such as BTCUSDT.
+func SplitPair(pair string) (base, quote string, ok bool) {
+	i := strings.IndexAny(pair, pairSeparators)
+	if i <= 0 || i == len(pair)-1 {
+		return "", "", false
+	}
+	return pair[:i], pair[i+1:], true
+}
+
+// AssetFilter matches pairs by base and quote asset case-insensitively,
+// an empty asset matches any asset.
+type AssetFilter struct {
+	Base  string
+	Quote string
+}
+
+func (f AssetFilter) Match(pair string) bool {
+	if f.Base == "" && f.Quote == "" {
+		return true
+	}
+	base, quote, ok := SplitPair(pair)
+	if !ok {
+		return false
+	}
+	return (f.Base == "" || strings.EqualFold(f.Base, base)) &&
+		(f.Quote == "" || strings.EqualFold(f.Quote, quote))
+}
@@ -0,0 +1,8 @@
+package domain
+
+type Side string
+
+const (
+	SideBid Side = "bid"
+	SideAsk Side = "ask"
+)
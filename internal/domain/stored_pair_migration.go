@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"log/slog"
+
+	"github.com/pkg/errors"
+)
+
+// StoredPairStorage is a storage holding data keyed by exchange and pair.
+type StoredPairStorage interface {
+	// GetStoredPairs returns the distinct pairs data is stored under.
+	GetStoredPairs() ([]OrderBookKey, error)
+	// RenameStoredPair moves the data of the exchange stored under the pair from to the pair to.
+	RenameStoredPair(exchangeName, from, to string) error
+}
+
+// MigrateStoredPairs renames pairs saved under exchange symbols before normalization
+// was enabled, BTCUSDT or btc-usdt, to their canonical form and returns the number of
+// renamed pairs. Pairs already in the canonical form are left untouched, so the migration
+// can be run repeatedly.
+func (n *SymbolNormalizer) MigrateStoredPairs(storage StoredPairStorage) (int, error) {
+	keys, err := storage.GetStoredPairs()
+	if err != nil {
+		return 0, errors.Wrap(err, "get stored pairs")
+	}
+
+	renamed := 0
+	for _, key := range keys {
+		pair := n.NormalizePair(key.ExchangeName, key.Pair)
+		if pair == key.Pair {
+			continue
+		}
+		err = storage.RenameStoredPair(key.ExchangeName, key.Pair, pair)
+		if err != nil {
+			return renamed, errors.Wrapf(err, "rename pair %s of %s to %s", key.Pair, key.ExchangeName, pair)
+		}
+		slog.Info("renamed stored pair", slog.String("exchange", key.ExchangeName),
+			slog.String("from", key.Pair), slog.String("to", pair))
+		renamed++
+	}
+	return renamed, nil
+}
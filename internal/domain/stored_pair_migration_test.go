@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStoredPairStorage holds stored pairs and records renames.
+type fakeStoredPairStorage struct {
+	keys    []OrderBookKey
+	renamed map[OrderBookKey]string
+}
+
+func (s *fakeStoredPairStorage) GetStoredPairs() ([]OrderBookKey, error) {
+	return s.keys, nil
+}
+
+func (s *fakeStoredPairStorage) RenameStoredPair(exchangeName, from, to string) error {
+	s.renamed[OrderBookKey{ExchangeName: exchangeName, Pair: from}] = to
+	return nil
+}
+
+func TestMigrateStoredPairs(t *testing.T) {
+	normalizer := NewSymbolNormalizer([]string{"USDT", "USD"}, map[string]string{"XBT": "BTC"},
+		map[string]string{"kraken/XXBTZUSD": "BTC_USD"})
+	storage := &fakeStoredPairStorage{
+		keys: []OrderBookKey{
+			{ExchangeName: "binance", Pair: "BTCUSDT"},
+			{ExchangeName: "okx", Pair: "btc-usdt"},
+			{ExchangeName: "kraken", Pair: "XBT/USD"},
+			{ExchangeName: "kraken", Pair: "XXBTZUSD"},
+			{ExchangeName: "bybit", Pair: "BTC_USDT"},
+			{ExchangeName: "bybit", Pair: "UNKNOWN"},
+		},
+		renamed: make(map[OrderBookKey]string),
+	}
+
+	renamed, err := normalizer.MigrateStoredPairs(storage)
+	require.NoError(t, err)
+	require.Equal(t, 4, renamed)
+	require.Equal(t, map[OrderBookKey]string{
+		{ExchangeName: "binance", Pair: "BTCUSDT"}: "BTC_USDT",
+		{ExchangeName: "okx", Pair: "btc-usdt"}:    "BTC_USDT",
+		{ExchangeName: "kraken", Pair: "XBT/USD"}:  "BTC_USD",
+		{ExchangeName: "kraken", Pair: "XXBTZUSD"}: "BTC_USD",
+	}, storage.renamed)
+
+	// canonical pairs are left as they are
+	storage.keys = []OrderBookKey{{ExchangeName: "binance", Pair: "BTC_USDT"}}
+	storage.renamed = make(map[OrderBookKey]string)
+	renamed, err = normalizer.MigrateStoredPairs(storage)
+	require.NoError(t, err)
+	require.Zero(t, renamed)
+	require.Empty(t, storage.renamed)
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SymbolNormalizer maps exchange specific pair symbols such as BTCUSDT, btc-usdt
+// or XBT/USDT to the canonical BASE_QUOTE form, BTC_USDT for all of them.
+type SymbolNormalizer struct {
+	// quoteAssets split symbols without a separator, sorted longest first.
+	quoteAssets []string
+	// assetAliases and symbolAliases are keyed by ASSET or SYMBOL for all exchanges
+	// and by exchange/ASSET or exchange/SYMBOL for a single exchange.
+	assetAliases  map[string]string
+	symbolAliases map[string]string
+}
+
+// NewSymbolNormalizer creates a normalizer. Symbols without a separator are split by the longest
+// of quoteAssets they end with. Asset aliases such as XBT:BTC rename assets, symbol aliases such as
+// XXBTZUSD:BTC_USD map whole symbols. Alias keys prefixed with "exchange/" apply to that exchange only.
+func NewSymbolNormalizer(quoteAssets []string, assetAliases, symbolAliases map[string]string) *SymbolNormalizer {
+	n := &SymbolNormalizer{
+		assetAliases:  make(map[string]string, len(assetAliases)),
+		symbolAliases: make(map[string]string, len(symbolAliases)),
+	}
+	for key, asset := range assetAliases {
+		n.assetAliases[normalizeAliasKey(key)] = strings.ToUpper(asset)
+	}
+	for key, pair := range symbolAliases {
+		n.symbolAliases[normalizeAliasKey(key)] = strings.ToUpper(pair)
+	}
+
+	seen := make(map[string]struct{}, len(quoteAssets))
+	for _, asset := range quoteAssets {
+		asset = strings.ToUpper(strings.TrimSpace(asset))
+		if _, ok := seen[asset]; ok || asset == "" {
+			continue
+		}
+		seen[asset] = struct{}{}
+		n.quoteAssets = append(n.quoteAssets, asset)
+	}
+	// aliases of quote assets, XBT for BTC, split symbols as well
+	for key, asset := range n.assetAliases {
+		alias := key
+		if _, exchangeAlias, ok := strings.Cut(key, "/"); ok {
+			alias = exchangeAlias
+		}
+		_, isQuote := seen[asset]
+		if _, ok := seen[alias]; isQuote && !ok {
+			seen[alias] = struct{}{}
+			n.quoteAssets = append(n.quoteAssets, alias)
+		}
+	}
+	sort.SliceStable(n.quoteAssets, func(i, j int) bool {
+		return len(n.quoteAssets[i]) > len(n.quoteAssets[j])
+	})
+
+	return n
+}
+
+// NormalizePair returns the canonical BASE_QUOTE form of the exchange's symbol.
+// A symbol that can not be split into assets is only upper-cased.
+func (n *SymbolNormalizer) NormalizePair(exchangeName, symbol string) string {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if pair, ok := n.lookup(n.symbolAliases, exchangeName, symbol); ok {
+		return pair
+	}
+
+	base, quote, ok := SplitPair(symbol)
+	if !ok {
+		base, quote, ok = n.splitByQuoteAsset(symbol)
+	}
+	if !ok {
+		return symbol
+	}
+	return n.NormalizeAsset(exchangeName, base) + "_" + n.NormalizeAsset(exchangeName, quote)
+}
+
+// SplitSymbol splits the exchange's symbol into canonical base and quote assets. An error is
+// returned for a symbol without a separator that does not end with any of the quote assets.
+func (n *SymbolNormalizer) SplitSymbol(exchangeName, symbol string) (base, quote string, err error) {
+	pair := n.NormalizePair(exchangeName, symbol)
+	base, quote, ok := SplitPair(pair)
+	if !ok {
+		return "", "", fmt.Errorf("symbol %q has no separator and no known quote asset", pair)
+	}
+	return base, quote, nil
+}
+
+// NormalizeAsset returns the canonical name of the exchange's asset.
+func (n *SymbolNormalizer) NormalizeAsset(exchangeName, asset string) string {
+	asset = strings.ToUpper(strings.TrimSpace(asset))
+	if alias, ok := n.lookup(n.assetAliases, exchangeName, asset); ok {
+		return alias
+	}
+	return asset
+}
+
+func (n *SymbolNormalizer) splitByQuoteAsset(symbol string) (base, quote string, ok bool) {
+	for _, quote := range n.quoteAssets {
+		if len(symbol) > len(quote) && strings.HasSuffix(symbol, quote) {
+			return symbol[:len(symbol)-len(quote)], quote, true
+		}
+	}
+	return "", "", false
+}
+
+// lookup prefers the alias of the exchange to the alias for all exchanges.
+func (n *SymbolNormalizer) lookup(aliases map[string]string, exchangeName, key string) (string, bool) {
+	if alias, ok := aliases[exchangeName+"/"+key]; ok {
+		return alias, true
+	}
+	alias, ok := aliases[key]
+	return alias, ok
+}
+
+// normalizeAliasKey upper-cases the alias of an "exchange/ALIAS" or "ALIAS" key.
+func normalizeAliasKey(key string) string {
+	exchangeName, alias, ok := strings.Cut(strings.TrimSpace(key), "/")
+	if !ok {
+		return strings.ToUpper(exchangeName)
+	}
+	return exchangeName + "/" + strings.ToUpper(alias)
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSymbolNormalizer() *SymbolNormalizer {
+	return NewSymbolNormalizer(
+		[]string{"USDT", "USD", "BTC", "EUR"},
+		map[string]string{"XBT": "BTC", "kraken/XDG": "DOGE", "bitfinex/UST": "USDT"},
+		map[string]string{"kraken/XXBTZUSD": "BTC_USD", "LUNA2USDT": "LUNA_USDT"},
+	)
+}
+
+func TestNormalizePair(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exchange string
+		symbol   string
+		pair     string
+	}{
+		{name: "NoSeparator", exchange: "binance", symbol: "BTCUSDT", pair: "BTC_USDT"},
+		{name: "Dash", exchange: "okx", symbol: "BTC-USDT", pair: "BTC_USDT"},
+		{name: "LowerCase", exchange: "gate", symbol: "btc_usdt", pair: "BTC_USDT"},
+		{name: "SlashWithAssetAlias", exchange: "kraken", symbol: "XBT/USDT", pair: "BTC_USDT"},
+		{name: "Colon", exchange: "bitfinex", symbol: "ETH:USD", pair: "ETH_USD"},
+		{name: "Whitespace", exchange: "binance", symbol: " ethbtc ", pair: "ETH_BTC"},
+		{name: "Canonical", exchange: "binance", symbol: "SOL_USDT", pair: "SOL_USDT"},
+
+		{name: "LongestQuoteFirst", exchange: "binance", symbol: "ETHUSDT", pair: "ETH_USDT"},
+		{name: "ShorterQuote", exchange: "coinbase", symbol: "ETHUSD", pair: "ETH_USD"},
+		{name: "BaseEndingWithQuote", exchange: "binance", symbol: "BTCUSDUSDT", pair: "BTCUSD_USDT"},
+		{name: "QuoteAliasSplits", exchange: "kraken", symbol: "ETHXBT", pair: "ETH_BTC"},
+
+		{name: "ExchangeAssetAlias", exchange: "kraken", symbol: "XDGUSD", pair: "DOGE_USD"},
+		{name: "ExchangeAssetAliasOtherExchange", exchange: "binance", symbol: "XDG_USD", pair: "XDG_USD"},
+		{name: "ExchangeQuoteAlias", exchange: "bitfinex", symbol: "BTC-UST", pair: "BTC_USDT"},
+		{name: "GlobalAssetAlias", exchange: "binance", symbol: "XBT_EUR", pair: "BTC_EUR"},
+		{name: "ExchangeSymbolAlias", exchange: "kraken", symbol: "XXBTZUSD", pair: "BTC_USD"},
+		{name: "ExchangeSymbolAliasOtherExchange", exchange: "binance", symbol: "XXBTZUSD", pair: "XXBTZ_USD"},
+		{name: "GlobalSymbolAlias", exchange: "bybit", symbol: "luna2usdt", pair: "LUNA_USDT"},
+
+		{name: "UnknownQuote", exchange: "binance", symbol: "btcjpy", pair: "BTCJPY"},
+		{name: "OnlyQuote", exchange: "binance", symbol: "USDT", pair: "USDT"},
+
+		{name: "NoExchange", exchange: "", symbol: "btcusdt", pair: "BTC_USDT"},
+		{name: "NoExchangeGlobalAlias", exchange: "", symbol: "XBT-USD", pair: "BTC_USD"},
+		{name: "NoExchangeSkipsExchangeAlias", exchange: "", symbol: "XDGUSD", pair: "XDG_USD"},
+	}
+
+	normalizer := newTestSymbolNormalizer()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.pair, normalizer.NormalizePair(tc.exchange, tc.symbol))
+		})
+	}
+}
+
+func TestSplitSymbol(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exchange string
+		symbol   string
+		base     string
+		quote    string
+		err      bool
+	}{
+		{name: "NoSeparator", exchange: "binance", symbol: "BTCUSDT", base: "BTC", quote: "USDT"},
+		{name: "AssetAlias", exchange: "kraken", symbol: "XDG/XBT", base: "DOGE", quote: "BTC"},
+		{name: "NoExchange", exchange: "", symbol: "ETHEUR", base: "ETH", quote: "EUR"},
+		{name: "UnknownQuote", exchange: "binance", symbol: "BTCJPY", err: true},
+		{name: "OnlyQuote", exchange: "binance", symbol: "USD", err: true},
+		{name: "Empty", exchange: "binance", symbol: "", err: true},
+	}
+
+	normalizer := newTestSymbolNormalizer()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			base, quote, err := normalizer.SplitSymbol(tc.exchange, tc.symbol)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.base, base)
+			require.Equal(t, tc.quote, quote)
+		})
+	}
+}
+
+func TestNormalizeAsset(t *testing.T) {
+	normalizer := newTestSymbolNormalizer()
+	require.Equal(t, "BTC", normalizer.NormalizeAsset("kraken", "xbt"))
+	require.Equal(t, "DOGE", normalizer.NormalizeAsset("kraken", "XDG"))
+	require.Equal(t, "XDG", normalizer.NormalizeAsset("binance", "XDG"))
+	require.Equal(t, "XDG", normalizer.NormalizeAsset("", "XDG"))
+	require.Equal(t, "ETH", normalizer.NormalizeAsset("", " eth "))
+}
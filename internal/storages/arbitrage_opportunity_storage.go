@@ -0,0 +1,54 @@
+package storages
+
+import (
+	"context"
+	"market-info-storage/internal/domain"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/pkg/errors"
+)
+
+type ArbitrageOpportunityStorage struct {
+	db driver.Conn
+}
+
+func NewArbitrageOpportunityStorage(db driver.Conn) *ArbitrageOpportunityStorage {
+	return &ArbitrageOpportunityStorage{
+		db: db,
+	}
+}
+
+func (s *ArbitrageOpportunityStorage) SaveArbitrageOpportunities(opportunities []domain.ArbitrageOpportunity) error {
+	batch, err := s.db.PrepareBatch(context.Background(), `
+		INSERT INTO arbitrage_opportunities (
+			pair,
+			buy_exchange,
+			sell_exchange,
+			best_ask,
+			best_bid,
+			base_qty,
+			buy_quote_qty,
+			sell_quote_qty,
+			profit,
+			profit_bps,
+			detected_at)`)
+	if err != nil {
+		return errors.Wrap(err, "prepare batch")
+	}
+
+	for _, opportunity := range opportunities {
+		err = batch.Append(
+			opportunity.Pair, opportunity.BuyExchange, opportunity.SellExchange, opportunity.BestAsk, opportunity.BestBid,
+			opportunity.BaseQty, opportunity.BuyQuoteQty, opportunity.SellQuoteQty, opportunity.Profit, opportunity.ProfitBps,
+			opportunity.DetectedAt)
+		if err != nil {
+			return errors.Wrap(err, "append to batch")
+		}
+	}
+	err = batch.Send()
+	if err != nil {
+		return errors.Wrap(err, "send batch")
+	}
+
+	return nil
+}
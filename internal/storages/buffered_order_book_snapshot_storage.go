@@ -0,0 +1,115 @@
+package storages
+
+import (
+	"context"
+	"expvar"
+	"log/slog"
+	"market-info-storage/internal/domain"
+	"market-info-storage/internal/utils/slogutils"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// orderBookSnapshotBufferStats are served at /debug/vars.
+var orderBookSnapshotBufferStats = expvar.NewMap("orderBookSnapshotBuffer")
+
+// BufferedOrderBookSnapshotStorage keeps saved snapshots in memory and writes them to ClickHouse
+// in batches every flushInterval or once maxBatchSize snapshots are pending, so saves of order books
+// do not wait for ClickHouse and every batch makes a single table part. Reads are passed through.
+// Snapshots of a batch that fails to be written are logged and dropped.
+type BufferedOrderBookSnapshotStorage struct {
+	domain.OrderBookSnapshotStorage
+
+	write         func(rows []orderBookSnapshotRow) error
+	flushInterval time.Duration
+	maxBatchSize  int
+
+	mu      sync.Mutex
+	pending []orderBookSnapshotRow
+	// full is signaled once maxBatchSize snapshots are pending.
+	full chan struct{}
+}
+
+func NewBufferedOrderBookSnapshotStorage(storage *OrderBookSnapshotStorage, flushInterval time.Duration, maxBatchSize int) *BufferedOrderBookSnapshotStorage {
+	return newBufferedOrderBookSnapshotStorage(storage, storage.saveOrderBookSnapshots, flushInterval, maxBatchSize)
+}
+
+func newBufferedOrderBookSnapshotStorage(
+	storage domain.OrderBookSnapshotStorage,
+	write func(rows []orderBookSnapshotRow) error,
+	flushInterval time.Duration,
+	maxBatchSize int,
+) *BufferedOrderBookSnapshotStorage {
+	return &BufferedOrderBookSnapshotStorage{
+		OrderBookSnapshotStorage: storage,
+		write:                    write,
+		flushInterval:            flushInterval,
+		maxBatchSize:             maxBatchSize,
+		full:                     make(chan struct{}, 1),
+	}
+}
+
+func (s *BufferedOrderBookSnapshotStorage) SaveOrderBookSnapshot(exchangeName, pair string, snapshot *domain.OrderBookSnapshot) error {
+	s.add([]orderBookSnapshotRow{{exchangeName: exchangeName, pair: pair, snapshot: snapshot}})
+	return nil
+}
+
+func (s *BufferedOrderBookSnapshotStorage) SaveOrderBookSnapshots(items []domain.OrderBookBatchItem, retentions map[string]time.Duration) error {
+	s.add(batchSnapshotRows(items, retentions))
+	return nil
+}
+
+func (s *BufferedOrderBookSnapshotStorage) add(rows []orderBookSnapshotRow) {
+	s.mu.Lock()
+	s.pending = append(s.pending, rows...)
+	full := s.maxBatchSize > 0 && len(s.pending) >= s.maxBatchSize
+	s.mu.Unlock()
+
+	if full {
+		select {
+		case s.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run writes pending snapshots until ctx is done, the remaining ones are written before it returns.
+func (s *BufferedOrderBookSnapshotStorage) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.Flush()
+			return
+		case <-ticker.C:
+			s.Flush()
+		case <-s.full:
+			s.Flush()
+		}
+	}
+}
+
+// Flush writes the pending snapshots with a single batch.
+func (s *BufferedOrderBookSnapshotStorage) Flush() {
+	s.mu.Lock()
+	rows := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+	if len(rows) == 0 {
+		return
+	}
+
+	err := s.write(rows)
+	if err != nil {
+		orderBookSnapshotBufferStats.Add("dropped", int64(len(rows)))
+		err = errors.Wrap(err, "write order book snapshots")
+		slog.Error("", slogutils.ErrorAttr(err), slog.Int("count", len(rows)))
+		return
+	}
+	orderBookSnapshotBufferStats.Add("written", int64(len(rows)))
+	orderBookSnapshotBufferStats.Add("batches", 1)
+}
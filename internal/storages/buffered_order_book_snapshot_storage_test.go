@@ -0,0 +1,95 @@
+package storages
+
+import (
+	"context"
+	"errors"
+	"market-info-storage/internal/domain"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordedSnapshotBatches keeps the sizes of written batches.
+type recordedSnapshotBatches struct {
+	mu      sync.Mutex
+	batches []int
+	err     error
+}
+
+func (r *recordedSnapshotBatches) write(rows []orderBookSnapshotRow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.batches = append(r.batches, len(rows))
+	return nil
+}
+
+func (r *recordedSnapshotBatches) sizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int{}, r.batches...)
+}
+
+func testSnapshot() *domain.OrderBookSnapshot {
+	return &domain.OrderBookSnapshot{Time: time.Now(), OrderBook: cacheTestOrderBook("100", 1)}
+}
+
+func TestBufferedOrderBookSnapshotStorageBatchSize(t *testing.T) {
+	recorded := &recordedSnapshotBatches{}
+	storage := newBufferedOrderBookSnapshotStorage(nil, recorded.write, time.Hour, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		storage.Run(ctx)
+		close(done)
+	}()
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, storage.SaveOrderBookSnapshot("bybit", "BTC_USDT", testSnapshot()))
+	}
+	// nothing is written until the batch is full
+	time.Sleep(20 * time.Millisecond)
+	require.Empty(t, recorded.sizes())
+
+	require.NoError(t, storage.SaveOrderBookSnapshots([]domain.OrderBookBatchItem{
+		{ExchangeName: "bybit", Pair: "ETH_USDT", OrderBook: cacheTestOrderBook("10", 1)},
+	}, nil))
+	require.Eventually(t, func() bool { return len(recorded.sizes()) == 1 }, time.Second, 5*time.Millisecond)
+	require.Equal(t, []int{3}, recorded.sizes())
+
+	// the remaining snapshots are written on stop
+	require.NoError(t, storage.SaveOrderBookSnapshot("bybit", "BTC_USDT", testSnapshot()))
+	cancel()
+	<-done
+	require.Equal(t, []int{3, 1}, recorded.sizes())
+}
+
+func TestBufferedOrderBookSnapshotStorageFlushInterval(t *testing.T) {
+	recorded := &recordedSnapshotBatches{}
+	storage := newBufferedOrderBookSnapshotStorage(nil, recorded.write, 10*time.Millisecond, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go storage.Run(ctx)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, storage.SaveOrderBookSnapshot("bybit", "BTC_USDT", testSnapshot()))
+	}
+	require.Eventually(t, func() bool { return len(recorded.sizes()) == 1 }, time.Second, 5*time.Millisecond)
+	require.Equal(t, []int{5}, recorded.sizes())
+}
+
+func TestBufferedOrderBookSnapshotStorageDropsFailedBatch(t *testing.T) {
+	recorded := &recordedSnapshotBatches{err: errors.New("clickhouse is down")}
+	storage := newBufferedOrderBookSnapshotStorage(nil, recorded.write, time.Hour, 1000)
+
+	require.NoError(t, storage.SaveOrderBookSnapshot("bybit", "BTC_USDT", testSnapshot()))
+	storage.Flush()
+
+	recorded.err = nil
+	storage.Flush()
+	require.Empty(t, recorded.sizes())
+}
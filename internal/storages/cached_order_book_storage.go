@@ -0,0 +1,220 @@
+package storages
+
+import (
+	"container/list"
+	"expvar"
+	"market-info-storage/internal/domain"
+	"slices"
+	"sync"
+	"time"
+)
+
+// orderBookCacheStats are served at /debug/vars.
+var orderBookCacheStats = expvar.NewMap("orderBookCache")
+
+// CachedOrderBookStorage keeps recently used order books in memory. Saves and updates
+// are written through, reads of single books are served from the cache until ttl expires.
+// The least recently used books are evicted when there are more than maxEntries.
+type CachedOrderBookStorage struct {
+	domain.OrderBookStorage
+
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[domain.OrderBookKey]*list.Element
+	// lru holds *orderBookCacheEntry with the most recently used at the front.
+	lru *list.List
+}
+
+type orderBookCacheEntry struct {
+	key       domain.OrderBookKey
+	orderBook *domain.OrderBook
+	expiresAt time.Time
+}
+
+func NewCachedOrderBookStorage(storage domain.OrderBookStorage, ttl time.Duration, maxEntries int) *CachedOrderBookStorage {
+	return &CachedOrderBookStorage{
+		OrderBookStorage: storage,
+		ttl:              ttl,
+		maxEntries:       maxEntries,
+		entries:          make(map[domain.OrderBookKey]*list.Element),
+		lru:              list.New(),
+	}
+}
+
+func (s *CachedOrderBookStorage) SaveOrderBook(exchangeName string, pair string, orderBook *domain.OrderBook) error {
+	err := s.OrderBookStorage.SaveOrderBook(exchangeName, pair, orderBook)
+	if err != nil {
+		s.remove(domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair})
+		return err
+	}
+
+	s.put(domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair}, orderBook)
+	return nil
+}
+
+func (s *CachedOrderBookStorage) SaveOrderBookIfMatch(exchangeName string, pair string, orderBook *domain.OrderBook, versions []int64) error {
+	err := s.OrderBookStorage.SaveOrderBookIfMatch(exchangeName, pair, orderBook, versions)
+	if err != nil {
+		s.remove(domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair})
+		return err
+	}
+
+	s.put(domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair}, orderBook)
+	return nil
+}
+
+func (s *CachedOrderBookStorage) SaveOrderBooks(items []domain.OrderBookBatchItem) error {
+	err := s.OrderBookStorage.SaveOrderBooks(items)
+	for _, item := range items {
+		key := domain.OrderBookKey{ExchangeName: item.ExchangeName, Pair: item.Pair}
+		if err != nil {
+			s.remove(key)
+		} else {
+			s.put(key, item.OrderBook)
+		}
+	}
+	return err
+}
+
+func (s *CachedOrderBookStorage) GetOrderBook(exchangeName string, pair string, opts domain.OrderBookReadOptions) (*domain.OrderBook, error) {
+	key := domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair}
+	orderBook, ok := s.get(key)
+	if ok {
+		orderBookCacheStats.Add("hits", 1)
+	} else {
+		orderBookCacheStats.Add("misses", 1)
+		var err error
+		orderBook, err = s.OrderBookStorage.GetOrderBook(exchangeName, pair, domain.OrderBookReadOptions{})
+		if err != nil {
+			return nil, err
+		}
+		s.put(key, orderBook)
+	}
+
+	return copyOrderBook(orderBook, opts), nil
+}
+
+func (s *CachedOrderBookStorage) UpdateOrderBook(exchangeName string, pair string, update func(orderBook *domain.OrderBook) error) error {
+	var updatedOrderBook *domain.OrderBook
+	err := s.OrderBookStorage.UpdateOrderBook(exchangeName, pair, func(orderBook *domain.OrderBook) error {
+		updatedOrderBook = orderBook
+		return update(orderBook)
+	})
+	key := domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair}
+	switch err.(type) {
+	case nil:
+		s.put(key, updatedOrderBook)
+	case domain.OrderBookInvalid, domain.OrderBookResyncRequired, domain.OrderBookChecksumMismatch, domain.ChecksumAlgorithmUnknown:
+		// the stored book is left untouched
+	default:
+		s.remove(key)
+	}
+	return err
+}
+
+func (s *CachedOrderBookStorage) DeleteOrderBook(exchangeName string, pair string) error {
+	err := s.OrderBookStorage.DeleteOrderBook(exchangeName, pair)
+	s.remove(domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair})
+	return err
+}
+
+func (s *CachedOrderBookStorage) DeleteExchangeOrderBooks(exchangeName string) (int64, error) {
+	count, err := s.OrderBookStorage.DeleteExchangeOrderBooks(exchangeName)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, element := range s.entries {
+		if key.ExchangeName == exchangeName {
+			s.removeElement(element)
+		}
+	}
+	return count, err
+}
+
+// HandleOrderBookChange drops the cached book if another instance or the sweeper changed or deleted it.
+func (s *CachedOrderBookStorage) HandleOrderBookChange(change domain.OrderBookChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	element, ok := s.entries[domain.OrderBookKey{ExchangeName: change.ExchangeName, Pair: change.Pair}]
+	if !ok {
+		return
+	}
+	if change.Deleted || element.Value.(*orderBookCacheEntry).orderBook.Version < change.Version {
+		s.removeElement(element)
+	}
+}
+
+// ResyncOrderBooks drops all cached books as changes may have been missed.
+func (s *CachedOrderBookStorage) ResyncOrderBooks() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.entries)
+	s.lru.Init()
+}
+
+func (s *CachedOrderBookStorage) get(key domain.OrderBookKey) (*domain.OrderBook, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	element, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*orderBookCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		s.removeElement(element)
+		return nil, false
+	}
+	s.lru.MoveToFront(element)
+	return entry.orderBook, true
+}
+
+// put caches a copy of the order book unless a newer version is already cached.
+func (s *CachedOrderBookStorage) put(key domain.OrderBookKey, orderBook *domain.OrderBook) {
+	entry := &orderBookCacheEntry{
+		key:       key,
+		orderBook: copyOrderBook(orderBook, domain.OrderBookReadOptions{}),
+		expiresAt: time.Now().Add(s.ttl),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if element, ok := s.entries[key]; ok {
+		if element.Value.(*orderBookCacheEntry).orderBook.Version > orderBook.Version {
+			return
+		}
+		element.Value = entry
+		s.lru.MoveToFront(element)
+		return
+	}
+
+	s.entries[key] = s.lru.PushFront(entry)
+	for s.lru.Len() > s.maxEntries {
+		s.removeElement(s.lru.Back())
+		orderBookCacheStats.Add("evictions", 1)
+	}
+}
+
+func (s *CachedOrderBookStorage) remove(key domain.OrderBookKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if element, ok := s.entries[key]; ok {
+		s.removeElement(element)
+	}
+}
+
+func (s *CachedOrderBookStorage) removeElement(element *list.Element) {
+	delete(s.entries, element.Value.(*orderBookCacheEntry).key)
+	s.lru.Remove(element)
+}
+
+// copyOrderBook copies the levels left by the depth and side of opts,
+// so that callers can not modify cached levels.
+func copyOrderBook(orderBook *domain.OrderBook, opts domain.OrderBookReadOptions) *domain.OrderBook {
+	orderBookCopy := *orderBook
+	orderBookCopy.ApplyReadOptions(domain.OrderBookReadOptions{Depth: opts.Depth, Side: opts.Side})
+	orderBookCopy.Bids = slices.Clone(orderBookCopy.Bids)
+	orderBookCopy.Asks = slices.Clone(orderBookCopy.Asks)
+	return &orderBookCopy
+}
@@ -0,0 +1,223 @@
+package storages
+
+import (
+	"expvar"
+	"market-info-storage/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrderBookStorage keeps books in memory and counts reads.
+type fakeOrderBookStorage struct {
+	domain.OrderBookStorage
+
+	orderBooks map[domain.OrderBookKey]*domain.OrderBook
+	reads      int
+}
+
+func newFakeOrderBookStorage() *fakeOrderBookStorage {
+	return &fakeOrderBookStorage{orderBooks: make(map[domain.OrderBookKey]*domain.OrderBook)}
+}
+
+func (s *fakeOrderBookStorage) SaveOrderBook(exchangeName string, pair string, orderBook *domain.OrderBook) error {
+	s.orderBooks[domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair}] = copyOrderBook(orderBook, domain.OrderBookReadOptions{})
+	return nil
+}
+
+func (s *fakeOrderBookStorage) GetOrderBook(exchangeName string, pair string, opts domain.OrderBookReadOptions) (*domain.OrderBook, error) {
+	s.reads++
+	orderBook, ok := s.orderBooks[domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair}]
+	if !ok {
+		return nil, domain.OrderBookNotFound{Message: "order book not found"}
+	}
+	return copyOrderBook(orderBook, opts), nil
+}
+
+func cacheTestOrderBook(price string, version int64) *domain.OrderBook {
+	return &domain.OrderBook{
+		Bids:    []domain.DepthOrder{{Price: decimal.RequireFromString(price), BaseQty: decimal.RequireFromString("1")}},
+		Version: version,
+	}
+}
+
+func cacheStat(name string) int64 {
+	if v, ok := orderBookCacheStats.Get(name).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+func TestCachedOrderBookStorageHitsAndMisses(t *testing.T) {
+	storage := newFakeOrderBookStorage()
+	storage.orderBooks[domain.OrderBookKey{ExchangeName: "bybit", Pair: "BTC_USDT"}] = cacheTestOrderBook("100", 1)
+	cache := NewCachedOrderBookStorage(storage, time.Minute, 10)
+	hits, misses := cacheStat("hits"), cacheStat("misses")
+
+	for i := 0; i < 3; i++ {
+		orderBook, err := cache.GetOrderBook("bybit", "BTC_USDT", domain.OrderBookReadOptions{})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), orderBook.Version)
+	}
+	_, err := cache.GetOrderBook("bybit", "ETH_USDT", domain.OrderBookReadOptions{})
+	require.IsType(t, domain.OrderBookNotFound{}, err)
+
+	require.Equal(t, 2, storage.reads)
+	require.Equal(t, hits+2, cacheStat("hits"))
+	require.Equal(t, misses+2, cacheStat("misses"))
+}
+
+func TestCachedOrderBookStorageTTL(t *testing.T) {
+	storage := newFakeOrderBookStorage()
+	storage.orderBooks[domain.OrderBookKey{ExchangeName: "bybit", Pair: "BTC_USDT"}] = cacheTestOrderBook("100", 1)
+	cache := NewCachedOrderBookStorage(storage, 20*time.Millisecond, 10)
+
+	_, err := cache.GetOrderBook("bybit", "BTC_USDT", domain.OrderBookReadOptions{})
+	require.NoError(t, err)
+	_, err = cache.GetOrderBook("bybit", "BTC_USDT", domain.OrderBookReadOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, storage.reads)
+
+	time.Sleep(30 * time.Millisecond)
+	_, err = cache.GetOrderBook("bybit", "BTC_USDT", domain.OrderBookReadOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 2, storage.reads)
+}
+
+func TestCachedOrderBookStorageEviction(t *testing.T) {
+	storage := newFakeOrderBookStorage()
+	cache := NewCachedOrderBookStorage(storage, time.Minute, 2)
+	evictions := cacheStat("evictions")
+
+	for i, pair := range []string{"BTC_USDT", "ETH_USDT"} {
+		err := cache.SaveOrderBook("bybit", pair, cacheTestOrderBook("100", int64(i+1)))
+		require.NoError(t, err)
+	}
+	// reading BTC_USDT makes ETH_USDT the least recently used book
+	_, err := cache.GetOrderBook("bybit", "BTC_USDT", domain.OrderBookReadOptions{})
+	require.NoError(t, err)
+	err = cache.SaveOrderBook("bybit", "SOL_USDT", cacheTestOrderBook("100", 3))
+	require.NoError(t, err)
+
+	require.Equal(t, evictions+1, cacheStat("evictions"))
+	require.Equal(t, 2, cache.lru.Len())
+	require.Contains(t, cache.entries, domain.OrderBookKey{ExchangeName: "bybit", Pair: "BTC_USDT"})
+	require.Contains(t, cache.entries, domain.OrderBookKey{ExchangeName: "bybit", Pair: "SOL_USDT"})
+	require.NotContains(t, cache.entries, domain.OrderBookKey{ExchangeName: "bybit", Pair: "ETH_USDT"})
+	require.Equal(t, 0, storage.reads)
+}
+
+func TestCachedOrderBookStorageKeepsNewerVersion(t *testing.T) {
+	storage := newFakeOrderBookStorage()
+	cache := NewCachedOrderBookStorage(storage, time.Minute, 10)
+	key := domain.OrderBookKey{ExchangeName: "bybit", Pair: "BTC_USDT"}
+
+	cache.put(key, cacheTestOrderBook("101", 5))
+	cache.put(key, cacheTestOrderBook("100", 4))
+
+	orderBook, err := cache.GetOrderBook("bybit", "BTC_USDT", domain.OrderBookReadOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), orderBook.Version)
+	require.Equal(t, decimal.RequireFromString("101"), orderBook.Bids[0].Price)
+	require.Equal(t, 0, storage.reads)
+}
+
+func TestCachedOrderBookStorageHandleOrderBookChange(t *testing.T) {
+	testCases := []struct {
+		name    string
+		change  domain.OrderBookChange
+		dropped bool
+	}{
+		{
+			name:    "NewerVersion",
+			change:  domain.OrderBookChange{ExchangeName: "bybit", Pair: "BTC_USDT", Version: 6},
+			dropped: true,
+		},
+		{
+			name:    "SameVersion",
+			change:  domain.OrderBookChange{ExchangeName: "bybit", Pair: "BTC_USDT", Version: 5},
+			dropped: false,
+		},
+		{
+			name:    "Deleted",
+			change:  domain.OrderBookChange{ExchangeName: "bybit", Pair: "BTC_USDT", Version: 5, Deleted: true},
+			dropped: true,
+		},
+		{
+			name:    "OtherBook",
+			change:  domain.OrderBookChange{ExchangeName: "bybit", Pair: "ETH_USDT", Version: 9},
+			dropped: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := newFakeOrderBookStorage()
+			cache := NewCachedOrderBookStorage(storage, time.Minute, 10)
+			err := cache.SaveOrderBook("bybit", "BTC_USDT", cacheTestOrderBook("100", 5))
+			require.NoError(t, err)
+
+			cache.HandleOrderBookChange(tc.change)
+
+			_, err = cache.GetOrderBook("bybit", "BTC_USDT", domain.OrderBookReadOptions{})
+			require.NoError(t, err)
+			if tc.dropped {
+				require.Equal(t, 1, storage.reads)
+			} else {
+				require.Equal(t, 0, storage.reads)
+			}
+		})
+	}
+}
+
+// sweptOrderBookStorage flags books stale the way Postgres does, with a new version
+// that the notify trigger passes to onChange.
+type sweptOrderBookStorage struct {
+	*fakeOrderBookStorage
+
+	version  int64
+	onChange func(change domain.OrderBookChange)
+}
+
+func (s *sweptOrderBookStorage) MarkOrderBooksStale(updatedBefore time.Time) (int64, error) {
+	var count int64
+	for key, orderBook := range s.orderBooks {
+		if orderBook.Stale || !orderBook.UpdatedAt.Before(updatedBefore) {
+			continue
+		}
+		s.version++
+		orderBook.Stale = true
+		orderBook.Version = s.version
+		count++
+		s.onChange(domain.OrderBookChange{ExchangeName: key.ExchangeName, Pair: key.Pair, Version: orderBook.Version})
+	}
+	return count, nil
+}
+
+func (s *sweptOrderBookStorage) DeleteOrderBooksUpdatedBefore(updatedBefore time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestCachedOrderBookStorageSweptBook(t *testing.T) {
+	storage := &sweptOrderBookStorage{fakeOrderBookStorage: newFakeOrderBookStorage(), version: 5}
+	cache := NewCachedOrderBookStorage(storage, time.Minute, 10)
+	storage.onChange = cache.HandleOrderBookChange
+	orderBook := cacheTestOrderBook("100", 5)
+	orderBook.UpdatedAt = time.Now().Add(-time.Hour)
+	err := cache.SaveOrderBook("bybit", "BTC_USDT", orderBook)
+	require.NoError(t, err)
+
+	cached, err := cache.GetOrderBook("bybit", "BTC_USDT", domain.OrderBookReadOptions{})
+	require.NoError(t, err)
+	require.False(t, cached.Stale)
+
+	domain.NewOrderBookSweeper(storage, time.Second, time.Minute, 0).Sweep()
+
+	swept, err := cache.GetOrderBook("bybit", "BTC_USDT", domain.OrderBookReadOptions{})
+	require.NoError(t, err)
+	require.True(t, swept.Stale)
+	require.Equal(t, int64(6), swept.Version)
+	require.Equal(t, 1, storage.reads)
+}
@@ -2,40 +2,64 @@ package storages
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"fmt"
-	"market-info-storage/internal/domain"
-	"strconv"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 )
 
-type DepthOrders []domain.DepthOrder
+// decimalArray is a NUMERIC[] column value. Prices and quantities of a side are stored
+// as two parallel arrays, each written as a single bind parameter.
+type decimalArray []decimal.Decimal
 
-func (a *DepthOrders) Scan(value interface{}) error {
-	arrayBytes, ok := value.([]byte) // value has a form of {\"(0.010782342,24)\",\"(0.010765101,11.5)\"}
+// Value encodes the array in its text form, {0.010782342,24}. Elements keep their trailing
+// zeros, NUMERIC stores the scale, so 0.10 is read back as 0.10 and checksums of levels
+// reloaded for a delta match the ones computed by the exchange.
+func (a decimalArray) Value() (driver.Value, error) {
+	buf := make([]byte, 0, 2+len(a)*16)
+	buf = append(buf, '{')
+	for i, d := range a {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if d.Exponent() < 0 {
+			buf = append(buf, d.StringFixed(-d.Exponent())...)
+		} else {
+			buf = append(buf, d.String()...)
+		}
+	}
+	buf = append(buf, '}')
+	return string(buf), nil
+}
+
+func (a *decimalArray) Scan(value interface{}) error {
+	arrayBytes, ok := value.([]byte) // value has a form of {0.010782342,24}
 	if !ok {
 		return fmt.Errorf("failed to convert value to []byte")
 	}
-	arrayBytes = arrayBytes[2 : len(arrayBytes)-2] // trim {\" and \"}
-
-	splitArrayBytes := bytes.Split(arrayBytes, []byte{'"', ',', '"'})
-	res := make([]domain.DepthOrder, 0, len(splitArrayBytes))
-	for _, depthOrderBytes := range splitArrayBytes {
-		depthOrderBytes = depthOrderBytes[1 : len(depthOrderBytes)-1] // trim ( and )
-		splitDepthOrderBytes := bytes.Split(depthOrderBytes, []byte{','})
+	if len(arrayBytes) < 2 || arrayBytes[0] != '{' || arrayBytes[len(arrayBytes)-1] != '}' {
+		return fmt.Errorf("malformed array %q", arrayBytes)
+	}
+	arrayBytes = arrayBytes[1 : len(arrayBytes)-1] // trim { and }
+	if len(arrayBytes) == 0 {
+		*a = decimalArray{}
+		return nil
+	}
 
-		var err error
-		var depthOrder domain.DepthOrder
-		depthOrder.Price, err = strconv.ParseFloat(string(splitDepthOrderBytes[0]), 64)
-		if err != nil {
-			return errors.Wrap(err, "parse DepthOrder.Price field")
+	res := make(decimalArray, 0, bytes.Count(arrayBytes, []byte{','})+1)
+	for len(arrayBytes) > 0 {
+		elemBytes := arrayBytes
+		if i := bytes.IndexByte(arrayBytes, ','); i >= 0 {
+			elemBytes, arrayBytes = arrayBytes[:i], arrayBytes[i+1:]
+		} else {
+			arrayBytes = nil
 		}
-		depthOrder.BaseQty, err = strconv.ParseFloat(string(splitDepthOrderBytes[1]), 64)
+		d, err := decimal.NewFromString(string(elemBytes))
 		if err != nil {
-			return errors.Wrap(err, "parse DepthOrder.BaseQty field")
+			return errors.Wrap(err, "parse array element")
 		}
-
-		res = append(res, depthOrder)
+		res = append(res, d)
 	}
 
 	*a = res
@@ -0,0 +1,144 @@
+package storages
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"market-info-storage/internal/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const exchangeColumns = "name, display_name, enabled, taker_fee, maker_fee, validation_mode, snapshot_retention_hours, updated_at"
+
+type ExchangeStorage struct {
+	db      *sqlx.DB
+	builder sq.StatementBuilderType
+}
+
+func NewExchangeStorage(db *sqlx.DB) *ExchangeStorage {
+	return &ExchangeStorage{
+		db:      db,
+		builder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func (s *ExchangeStorage) SaveExchange(exchange *domain.Exchange) error {
+	builder := s.builder.
+		Insert("exchanges").
+		Columns(exchangeColumns).
+		Values(exchange.Name, exchange.DisplayName, exchange.Enabled, exchange.TakerFee, exchange.MakerFee,
+			string(exchange.ValidationMode), exchange.SnapshotRetentionHours, exchange.UpdatedAt).
+		Suffix(`ON CONFLICT (name)
+				DO UPDATE SET display_name = EXCLUDED.display_name, enabled = EXCLUDED.enabled,
+				taker_fee = EXCLUDED.taker_fee, maker_fee = EXCLUDED.maker_fee, validation_mode = EXCLUDED.validation_mode,
+				snapshot_retention_hours = EXCLUDED.snapshot_retention_hours, updated_at = EXCLUDED.updated_at`)
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	_, err = s.db.Exec(query, args...)
+	if err != nil {
+		return errors.Wrap(err, "execute query")
+	}
+
+	return nil
+}
+
+func (s *ExchangeStorage) GetExchange(exchangeName string) (*domain.Exchange, error) {
+	builder := s.builder.
+		Select(exchangeColumns).
+		From("exchanges").
+		Where(sq.Eq{"name": exchangeName})
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	var exchange domain.Exchange
+	err = s.db.QueryRowx(query, args...).Scan(exchangeDest(&exchange)...)
+	if err == sql.ErrNoRows {
+		return nil, domain.ExchangeNotFound{Message: "exchange not found"}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+
+	return &exchange, nil
+}
+
+func (s *ExchangeStorage) GetExchanges() ([]domain.Exchange, error) {
+	builder := s.builder.
+		Select(exchangeColumns).
+		From("exchanges").
+		OrderBy("name")
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	rows, err := s.db.Queryx(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	defer rows.Close()
+
+	exchanges := []domain.Exchange{}
+	for rows.Next() {
+		var exchange domain.Exchange
+		err := rows.Scan(exchangeDest(&exchange)...)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan values")
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "iterate rows")
+	}
+
+	return exchanges, nil
+}
+
+func (s *ExchangeStorage) DeleteExchange(exchangeName string) error {
+	builder := s.builder.
+		Delete("exchanges").
+		Where(sq.Eq{"name": exchangeName})
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	res, err := s.db.Exec(query, args...)
+	if err != nil {
+		return errors.Wrap(err, "execute query")
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get affected rows")
+	}
+	if count == 0 {
+		return domain.ExchangeNotFound{Message: "exchange not found"}
+	}
+
+	return nil
+}
+
+// exchangeDest returns scan destinations in the order of exchangeColumns,
+// NULL fees are scanned as nil.
+func exchangeDest(exchange *domain.Exchange) []any {
+	return []any{
+		&exchange.Name, &exchange.DisplayName, &exchange.Enabled, &exchange.TakerFee, &exchange.MakerFee,
+		&exchange.ValidationMode, &exchange.SnapshotRetentionHours, &exchange.UpdatedAt,
+	}
+}
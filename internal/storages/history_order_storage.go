@@ -95,3 +95,112 @@ func (s *HistoryOrderStorage) GetHistoryOrdersByClient(client *domain.Client) ([
 
 	return historyOrders, nil
 }
+
+func (s *HistoryOrderStorage) GetHistoryOrderSummaries(exchangeName string) (map[string]map[string]domain.HistoryOrderSummary, error) {
+	rows, err := s.db.Query(context.Background(), `
+		SELECT
+			exchange_name,
+			pair,
+			count(),
+			max(time_placed)
+		FROM history_orders
+		WHERE
+			? = '' OR
+			exchange_name = ?
+		GROUP BY exchange_name, pair`,
+		exchangeName, exchangeName)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	defer rows.Close()
+
+	summaries := make(map[string]map[string]domain.HistoryOrderSummary)
+	for rows.Next() {
+		var exchangeName, pair string
+		var summary domain.HistoryOrderSummary
+		err := rows.Scan(&exchangeName, &pair, &summary.Count, &summary.LastTimePlaced)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan values")
+		}
+		if summaries[exchangeName] == nil {
+			summaries[exchangeName] = make(map[string]domain.HistoryOrderSummary)
+		}
+		summaries[exchangeName][pair] = summary
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "iterate rows")
+	}
+
+	return summaries, nil
+}
+
+// GetStoredPairs returns the distinct pairs history orders are stored under.
+func (s *HistoryOrderStorage) GetStoredPairs() ([]domain.OrderBookKey, error) {
+	return getStoredPairs(s.db, "SELECT DISTINCT exchange_name, pair FROM history_orders")
+}
+
+// RenameStoredPair copies the orders of the pair under the new name and deletes the old rows,
+// pair is a sorting key column and can not be updated in place. The deletion waits for the mutation.
+func (s *HistoryOrderStorage) RenameStoredPair(exchangeName, from, to string) error {
+	err := s.db.Exec(context.Background(), `
+		INSERT INTO history_orders
+		SELECT
+			client_name,
+			exchange_name,
+			label,
+			? AS pair,
+			side,
+			type,
+			base_qty,
+			price,
+			algorithm_name_placed,
+			lowest_sell_prc,
+			highest_buy_prc,
+			commission_quote_qty,
+			time_placed
+		FROM history_orders
+		WHERE
+			exchange_name = ? AND
+			pair = ?`,
+		to, exchangeName, from)
+	if err != nil {
+		return errors.Wrap(err, "copy orders")
+	}
+
+	err = s.db.Exec(context.Background(), `
+		ALTER TABLE history_orders
+		DELETE WHERE
+			exchange_name = ? AND
+			pair = ?
+		SETTINGS mutations_sync = 1`,
+		exchangeName, from)
+	if err != nil {
+		return errors.Wrap(err, "delete orders")
+	}
+
+	return nil
+}
+
+// getStoredPairs runs the query returning distinct exchange and pair columns.
+func getStoredPairs(db driver.Conn, query string) ([]domain.OrderBookKey, error) {
+	rows, err := db.Query(context.Background(), query)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	defer rows.Close()
+
+	var keys []domain.OrderBookKey
+	for rows.Next() {
+		var key domain.OrderBookKey
+		err := rows.Scan(&key.ExchangeName, &key.Pair)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan values")
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "iterate rows")
+	}
+
+	return keys, nil
+}
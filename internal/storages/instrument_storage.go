@@ -0,0 +1,146 @@
+package storages
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"market-info-storage/internal/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const instrumentColumns = "exchange, pair, base, quote, tick_size, qty_step, min_notional, updated_at"
+
+type InstrumentStorage struct {
+	db      *sqlx.DB
+	builder sq.StatementBuilderType
+}
+
+func NewInstrumentStorage(db *sqlx.DB) *InstrumentStorage {
+	return &InstrumentStorage{
+		db:      db,
+		builder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func (s *InstrumentStorage) SaveInstrument(instrument *domain.Instrument) error {
+	builder := s.builder.
+		Insert("instruments").
+		Columns(instrumentColumns).
+		Values(instrument.ExchangeName, instrument.Pair, instrument.Base, instrument.Quote,
+			instrument.TickSize, instrument.QtyStep, instrument.MinNotional, instrument.UpdatedAt).
+		Suffix(`ON CONFLICT (exchange, pair)
+				DO UPDATE SET base = EXCLUDED.base, quote = EXCLUDED.quote, tick_size = EXCLUDED.tick_size,
+				qty_step = EXCLUDED.qty_step, min_notional = EXCLUDED.min_notional, updated_at = EXCLUDED.updated_at`)
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	_, err = s.db.Exec(query, args...)
+	if err != nil {
+		return errors.Wrap(err, "execute query")
+	}
+
+	return nil
+}
+
+func (s *InstrumentStorage) GetInstrument(exchangeName string, pair string) (*domain.Instrument, error) {
+	builder := s.builder.
+		Select(instrumentColumns).
+		From("instruments").
+		Where(sq.And{sq.Eq{"exchange": exchangeName}, sq.Eq{"pair": pair}})
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	var instrument domain.Instrument
+	err = s.db.QueryRowx(query, args...).Scan(instrumentDest(&instrument)...)
+	if err == sql.ErrNoRows {
+		return nil, domain.InstrumentNotFound{Message: "instrument not found"}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+
+	return &instrument, nil
+}
+
+// GetInstruments returns instruments of the exchange, of all exchanges if exchangeName is empty.
+func (s *InstrumentStorage) GetInstruments(exchangeName string) ([]domain.Instrument, error) {
+	builder := s.builder.
+		Select(instrumentColumns).
+		From("instruments").
+		OrderBy("exchange", "pair")
+	if exchangeName != "" {
+		builder = builder.Where(sq.Eq{"exchange": exchangeName})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	rows, err := s.db.Queryx(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	defer rows.Close()
+
+	instruments := []domain.Instrument{}
+	for rows.Next() {
+		var instrument domain.Instrument
+		err := rows.Scan(instrumentDest(&instrument)...)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan values")
+		}
+		instruments = append(instruments, instrument)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "iterate rows")
+	}
+
+	return instruments, nil
+}
+
+func (s *InstrumentStorage) DeleteInstrument(exchangeName string, pair string) error {
+	builder := s.builder.
+		Delete("instruments").
+		Where(sq.And{sq.Eq{"exchange": exchangeName}, sq.Eq{"pair": pair}})
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	res, err := s.db.Exec(query, args...)
+	if err != nil {
+		return errors.Wrap(err, "execute query")
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get affected rows")
+	}
+	if count == 0 {
+		return domain.InstrumentNotFound{Message: "instrument not found"}
+	}
+
+	return nil
+}
+
+// instrumentDest returns scan destinations in the order of instrumentColumns.
+func instrumentDest(instrument *domain.Instrument) []any {
+	return []any{
+		&instrument.ExchangeName, &instrument.Pair, &instrument.Base, &instrument.Quote,
+		&instrument.TickSize, &instrument.QtyStep, &instrument.MinNotional, &instrument.UpdatedAt,
+	}
+}
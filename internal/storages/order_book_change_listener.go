@@ -0,0 +1,72 @@
+package storages
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"market-info-storage/internal/domain"
+	"market-info-storage/internal/utils/slogutils"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// orderBookChangesChannel is notified by the order_books trigger on every insert, version update and delete.
+const orderBookChangesChannel = "order_book_changes"
+
+const listenerPingInterval = 90 * time.Second
+
+type OrderBookChangeListener struct {
+	listener *pq.Listener
+}
+
+type OrderBookChangeHandler interface {
+	HandleOrderBookChange(change domain.OrderBookChange)
+	// ResyncOrderBooks is called after a reconnect as notifications may have been lost.
+	ResyncOrderBooks()
+}
+
+func NewOrderBookChangeListener(listener *pq.Listener) *OrderBookChangeListener {
+	return &OrderBookChangeListener{
+		listener: listener,
+	}
+}
+
+// Listen passes order book change notifications to handlers until ctx is done.
+func (l *OrderBookChangeListener) Listen(ctx context.Context, handlers ...OrderBookChangeHandler) error {
+	defer l.listener.Close()
+	err := l.listener.Listen(orderBookChangesChannel)
+	if err != nil {
+		return errors.Wrap(err, "listen")
+	}
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// a failed ping makes the listener reconnect
+			go l.listener.Ping()
+		case notification := <-l.listener.Notify:
+			if notification == nil {
+				for _, handler := range handlers {
+					handler.ResyncOrderBooks()
+				}
+				continue
+			}
+			var change domain.OrderBookChange
+			err := json.Unmarshal([]byte(notification.Extra), &change)
+			if err != nil {
+				err = errors.Wrap(err, "parse order book change")
+				slog.Error("", slogutils.ErrorAttr(err))
+				continue
+			}
+			for _, handler := range handlers {
+				handler.HandleOrderBookChange(change)
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package storages
+
+import (
+	"context"
+	"database/sql"
+	"market-info-storage/internal/domain"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type OrderBookSnapshotStorage struct {
+	db driver.Conn
+}
+
+func NewOrderBookSnapshotStorage(db driver.Conn) *OrderBookSnapshotStorage {
+	return &OrderBookSnapshotStorage{
+		db: db,
+	}
+}
+
+// orderBookSnapshotRow is a snapshot together with the book it was taken of.
+type orderBookSnapshotRow struct {
+	exchangeName string
+	pair         string
+	snapshot     *domain.OrderBookSnapshot
+}
+
+func (s *OrderBookSnapshotStorage) SaveOrderBookSnapshot(exchangeName, pair string, snapshot *domain.OrderBookSnapshot) error {
+	return s.saveOrderBookSnapshots([]orderBookSnapshotRow{{exchangeName: exchangeName, pair: pair, snapshot: snapshot}})
+}
+
+// SaveOrderBookSnapshots saves order books of a batch as snapshots taken at their update time.
+func (s *OrderBookSnapshotStorage) SaveOrderBookSnapshots(items []domain.OrderBookBatchItem, retentions map[string]time.Duration) error {
+	return s.saveOrderBookSnapshots(batchSnapshotRows(items, retentions))
+}
+
+// saveOrderBookSnapshots inserts the snapshots with a single batch.
+func (s *OrderBookSnapshotStorage) saveOrderBookSnapshots(rows []orderBookSnapshotRow) error {
+	batch, err := s.db.PrepareBatch(context.Background(), `
+		INSERT INTO order_book_snapshots (
+			exchange,
+			pair,
+			time,
+			bid_prices,
+			bid_qtys,
+			ask_prices,
+			ask_qtys,
+			sequence,
+			retention_hours)`)
+	if err != nil {
+		return errors.Wrap(err, "prepare batch")
+	}
+
+	for _, row := range rows {
+		bidPrices, bidQtys := splitDepthOrders(row.snapshot.OrderBook.Bids)
+		askPrices, askQtys := splitDepthOrders(row.snapshot.OrderBook.Asks)
+		// the table TTL deletes the snapshot once its retention has passed
+		retentionHours := uint32(row.snapshot.Retention / time.Hour)
+		err = batch.Append(row.exchangeName, row.pair, row.snapshot.Time, bidPrices, bidQtys, askPrices, askQtys,
+			row.snapshot.OrderBook.Sequence, retentionHours)
+		if err != nil {
+			return errors.Wrap(err, "append to batch")
+		}
+	}
+	err = batch.Send()
+	if err != nil {
+		return errors.Wrap(err, "send batch")
+	}
+
+	return nil
+}
+
+// batchSnapshotRows makes snapshots of batch items taken at their update time.
+func batchSnapshotRows(items []domain.OrderBookBatchItem, retentions map[string]time.Duration) []orderBookSnapshotRow {
+	rows := make([]orderBookSnapshotRow, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, orderBookSnapshotRow{
+			exchangeName: item.ExchangeName,
+			pair:         item.Pair,
+			snapshot: &domain.OrderBookSnapshot{
+				Time:      item.OrderBook.UpdatedAt,
+				OrderBook: item.OrderBook,
+				Retention: retentions[item.ExchangeName],
+			},
+		})
+	}
+	return rows
+}
+
+// GetOrderBookSnapshot returns the latest snapshot saved at or before at.
+func (s *OrderBookSnapshotStorage) GetOrderBookSnapshot(exchangeName, pair string, at time.Time) (*domain.OrderBookSnapshot, error) {
+	row := s.db.QueryRow(context.Background(), `
+		SELECT
+			time,
+			bid_prices,
+			bid_qtys,
+			ask_prices,
+			ask_qtys,
+			sequence
+		FROM order_book_snapshots
+		WHERE
+			exchange = ? AND
+			pair = ? AND
+			time <= ?
+		ORDER BY time DESC
+		LIMIT 1`,
+		exchangeName, pair, at)
+
+	var snapshotTime time.Time
+	var bidPrices, bidQtys, askPrices, askQtys []decimal.Decimal
+	var sequence *int64
+	err := row.Scan(&snapshotTime, &bidPrices, &bidQtys, &askPrices, &askQtys, &sequence)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return nil, domain.OrderBookNotFound{Message: "order book snapshot not found"}
+	default:
+		return nil, errors.Wrap(err, "execute query")
+	}
+
+	return &domain.OrderBookSnapshot{
+		Time: snapshotTime,
+		OrderBook: &domain.OrderBook{
+			Bids:      joinDepthOrders(bidPrices, bidQtys),
+			Asks:      joinDepthOrders(askPrices, askQtys),
+			Sequence:  sequence,
+			UpdatedAt: snapshotTime,
+		},
+	}, nil
+}
+
+func (s *OrderBookSnapshotStorage) GetOrderBookSnapshotTimes(exchangeName, pair string, from, to time.Time, limit int) ([]time.Time, error) {
+	rows, err := s.db.Query(context.Background(), `
+		SELECT time
+		FROM order_book_snapshots
+		WHERE
+			exchange = ? AND
+			pair = ? AND
+			time >= ? AND
+			time <= ?
+		ORDER BY time
+		LIMIT ?`,
+		exchangeName, pair, from, to, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	defer rows.Close()
+
+	var snapshotTimes []time.Time
+	for rows.Next() {
+		var snapshotTime time.Time
+		err := rows.Scan(&snapshotTime)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan values")
+		}
+		snapshotTimes = append(snapshotTimes, snapshotTime)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "iterate rows")
+	}
+
+	return snapshotTimes, nil
+}
+
+// GetStoredPairs returns the distinct pairs snapshots are stored under.
+func (s *OrderBookSnapshotStorage) GetStoredPairs() ([]domain.OrderBookKey, error) {
+	return getStoredPairs(s.db, "SELECT DISTINCT exchange, pair FROM order_book_snapshots")
+}
+
+// RenameStoredPair copies the snapshots of the pair under the new name and deletes the old rows,
+// pair is a sorting key column and can not be updated in place. The deletion waits for the mutation.
+func (s *OrderBookSnapshotStorage) RenameStoredPair(exchangeName, from, to string) error {
+	err := s.db.Exec(context.Background(), `
+		INSERT INTO order_book_snapshots
+		SELECT
+			exchange,
+			? AS pair,
+			time,
+			bid_prices,
+			bid_qtys,
+			ask_prices,
+			ask_qtys,
+			sequence,
+			retention_hours
+		FROM order_book_snapshots
+		WHERE
+			exchange = ? AND
+			pair = ?`,
+		to, exchangeName, from)
+	if err != nil {
+		return errors.Wrap(err, "copy snapshots")
+	}
+
+	err = s.db.Exec(context.Background(), `
+		ALTER TABLE order_book_snapshots
+		DELETE WHERE
+			exchange = ? AND
+			pair = ?
+		SETTINGS mutations_sync = 1`,
+		exchangeName, from)
+	if err != nil {
+		return errors.Wrap(err, "delete snapshots")
+	}
+
+	return nil
+}
+
+func splitDepthOrders(depthOrders []domain.DepthOrder) (prices, qtys []decimal.Decimal) {
+	prices = make([]decimal.Decimal, 0, len(depthOrders))
+	qtys = make([]decimal.Decimal, 0, len(depthOrders))
+	for _, depthOrder := range depthOrders {
+		prices = append(prices, depthOrder.Price)
+		qtys = append(qtys, depthOrder.BaseQty)
+	}
+	return prices, qtys
+}
+
+func joinDepthOrders(prices, qtys []decimal.Decimal) []domain.DepthOrder {
+	depthOrders := make([]domain.DepthOrder, 0, len(prices))
+	for i := range prices {
+		depthOrders = append(depthOrders, domain.DepthOrder{Price: prices[i], BaseQty: qtys[i]})
+	}
+	return depthOrders
+}
@@ -1,11 +1,14 @@
 package storages
 
 import (
+	"cmp"
 	"database/sql"
 	"fmt"
 	"log/slog"
 	"market-info-storage/internal/domain"
+	"slices"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
@@ -24,69 +27,500 @@ func NewOrderBookStorage(db *sqlx.DB) *OrderBookStorage {
 	}
 }
 
-func (s *OrderBookStorage) SaveOrderBook(exchangeName string, pair string, bids, asks []domain.DepthOrder) error {
-	bidsExpr := sq.Expr(buildDepthOrderArrayExprSQL(bids), flattenDepthOrders(bids)...)
-	asksExpr := sq.Expr(buildDepthOrderArrayExprSQL(asks), flattenDepthOrders(asks)...)
+func (s *OrderBookStorage) SaveOrderBook(exchangeName string, pair string, orderBook *domain.OrderBook) error {
+	return s.saveOrderBook(s.db, exchangeName, pair, orderBook)
+}
+
+// SaveOrderBooks saves order books of a batch in one transaction.
+func (s *OrderBookStorage) SaveOrderBooks(items []domain.OrderBookBatchItem) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction")
+	}
+	defer tx.Rollback()
+
+	for _, item := range sortByOrderBookKey(items) {
+		err = s.saveOrderBook(tx, item.ExchangeName, item.Pair, item.OrderBook)
+		if err != nil {
+			return errors.Wrapf(err, "save order book of %s on %s", item.Pair, item.ExchangeName)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "commit transaction")
+	}
+
+	return nil
+}
+
+// sortByOrderBookKey returns a copy of items sorted by exchange and pair. Transactions
+// saving batches lock rows in the same order, so overlapping batches cannot deadlock.
+func sortByOrderBookKey(items []domain.OrderBookBatchItem) []domain.OrderBookBatchItem {
+	sorted := slices.Clone(items)
+	slices.SortStableFunc(sorted, func(a, b domain.OrderBookBatchItem) int {
+		return cmp.Or(strings.Compare(a.ExchangeName, b.ExchangeName), strings.Compare(a.Pair, b.Pair))
+	})
+	return sorted
+}
+
+func (s *OrderBookStorage) SaveOrderBookIfMatch(exchangeName string, pair string, orderBook *domain.OrderBook, versions []int64) error {
+	where := sq.And{sq.Eq{"exchange": exchangeName}, sq.Eq{"pair": pair}}
+	if len(versions) > 0 {
+		where = append(where, sq.Eq{"version": versions})
+	}
 	builder := s.builder.
+		Update("order_books").
+		SetMap(depthOrderColumnValues(orderBook)).
+		Set("sequence", orderBook.Sequence).
+		Set("resync_required", false).
+		Set("updated_at", orderBook.UpdatedAt).
+		Set("exchange_time", orderBook.ExchangeTime).
+		Set("stale", false).
+		Set("version", sq.Expr("nextval('order_book_version_seq')")).
+		Where(where).
+		Suffix("RETURNING version")
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	err = s.db.QueryRowx(query, args...).Scan(&orderBook.Version)
+	if err == sql.ErrNoRows {
+		return domain.OrderBookVersionMismatch{Message: "order book version does not match"}
+	}
+	if err != nil {
+		return errors.Wrap(err, "execute query")
+	}
+
+	return nil
+}
+
+// saveOrderBook upserts the order book and sets its new version.
+func (s *OrderBookStorage) saveOrderBook(db sqlx.Queryer, exchangeName string, pair string, orderBook *domain.OrderBook) error {
+	query, args, err := s.saveOrderBookBuilder(exchangeName, pair, orderBook).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	err = db.QueryRowx(query, args...).Scan(&orderBook.Version)
+	if err != nil {
+		return errors.Wrap(err, "execute query")
+	}
+
+	return nil
+}
+
+// saveOrderBookBuilder builds the upsert of the order book, each side is passed
+// as two array parameters whatever the number of levels is.
+func (s *OrderBookStorage) saveOrderBookBuilder(exchangeName string, pair string, orderBook *domain.OrderBook) sq.InsertBuilder {
+	bidPrices, bidQtys := splitDepthOrders(orderBook.Bids)
+	askPrices, askQtys := splitDepthOrders(orderBook.Asks)
+	return s.builder.
 		Insert("order_books").
-		Columns("exchange, pair, bids, asks").
-		Values(exchangeName, pair, bidsExpr, asksExpr).
+		Columns("exchange, pair, bid_prices, bid_qtys, ask_prices, ask_qtys, sequence, updated_at, exchange_time").
+		Values(exchangeName, pair, decimalArray(bidPrices), decimalArray(bidQtys), decimalArray(askPrices), decimalArray(askQtys),
+			orderBook.Sequence, orderBook.UpdatedAt, orderBook.ExchangeTime).
 		Suffix(`ON CONFLICT (exchange, pair)
-				DO UPDATE SET bids = ?, asks = ?`,
-			bidsExpr, asksExpr)
+				DO UPDATE SET bid_prices = EXCLUDED.bid_prices, bid_qtys = EXCLUDED.bid_qtys,
+				ask_prices = EXCLUDED.ask_prices, ask_qtys = EXCLUDED.ask_qtys, sequence = EXCLUDED.sequence,
+				resync_required = FALSE, updated_at = EXCLUDED.updated_at, exchange_time = EXCLUDED.exchange_time,
+				stale = FALSE, version = nextval('order_book_version_seq')
+			RETURNING version`)
+}
+
+func (s *OrderBookStorage) GetOrderBook(exchangeName string, pair string, opts domain.OrderBookReadOptions) (*domain.OrderBook, error) {
+	builder := s.builder.
+		Select().
+		Column(depthOrderArrayColumns("bid", opts.Side == domain.SideAsk, opts.Depth)).
+		Column(depthOrderArrayColumns("ask", opts.Side == domain.SideBid, opts.Depth)).
+		Columns(orderBookStateColumns).
+		From("order_books").
+		Where(sq.And{sq.Eq{"exchange": exchangeName}, sq.Eq{"pair": pair}})
+
+	return s.getOrderBook(s.db, builder)
+}
+
+// GetOrderBooks returns order books by pair and exchange name. Books of all
+// pairs or exchanges are returned if pairs or exchangeNames are empty.
+func (s *OrderBookStorage) GetOrderBooks(pairs, exchangeNames []string, opts domain.OrderBookReadOptions) (map[string]map[string]*domain.OrderBook, error) {
+	where := sq.And{}
+	if len(pairs) > 0 {
+		where = append(where, sq.Eq{"pair": pairs})
+	}
+	if len(exchangeNames) > 0 {
+		where = append(where, sq.Eq{"exchange": exchangeNames})
+	}
+	builder := s.builder.
+		Select("pair, exchange").
+		Column(depthOrderArrayColumns("bid", opts.Side == domain.SideAsk, opts.Depth)).
+		Column(depthOrderArrayColumns("ask", opts.Side == domain.SideBid, opts.Depth)).
+		Columns(orderBookStateColumns).
+		From("order_books").
+		Where(where)
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	rows, err := s.db.Queryx(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	defer rows.Close()
+
+	orderBooks := make(map[string]map[string]*domain.OrderBook)
+	for rows.Next() {
+		var pair, exchangeName string
+		var row orderBookRow
+		err := rows.Scan(append([]any{&pair, &exchangeName}, row.dest()...)...)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan values")
+		}
+		if orderBooks[pair] == nil {
+			orderBooks[pair] = make(map[string]*domain.OrderBook)
+		}
+		orderBooks[pair][exchangeName] = row.orderBook()
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "iterate rows")
+	}
+
+	return orderBooks, nil
+}
+
+func (s *OrderBookStorage) GetOrderBookSummaries(exchangeName string) (map[string]map[string]domain.OrderBookSummary, error) {
+	builder := s.builder.
+		Select("exchange, pair, updated_at, cardinality(bid_prices), cardinality(ask_prices), stale").
+		From("order_books")
+	if exchangeName != "" {
+		builder = builder.Where(sq.Eq{"exchange": exchangeName})
+	}
 
 	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	defer rows.Close()
+
+	summaries := make(map[string]map[string]domain.OrderBookSummary)
+	for rows.Next() {
+		var exchangeName, pair string
+		var summary domain.OrderBookSummary
+		err := rows.Scan(&exchangeName, &pair, &summary.UpdatedAt, &summary.BidLevels, &summary.AskLevels, &summary.Stale)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan values")
+		}
+		if summaries[exchangeName] == nil {
+			summaries[exchangeName] = make(map[string]domain.OrderBookSummary)
+		}
+		summaries[exchangeName][pair] = summary
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "iterate rows")
+	}
+
+	return summaries, nil
+}
+
+func (s *OrderBookStorage) UpdateOrderBook(exchangeName string, pair string, update func(orderBook *domain.OrderBook) error) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction")
+	}
+	defer tx.Rollback()
+
+	selectBuilder := s.builder.
+		Select("bid_prices, bid_qtys, ask_prices, ask_qtys").
+		Columns(orderBookStateColumns).
+		From("order_books").
+		Where(sq.And{sq.Eq{"exchange": exchangeName}, sq.Eq{"pair": pair}}).
+		Suffix("FOR UPDATE")
+	orderBook, err := s.getOrderBook(tx, selectBuilder)
+	if err != nil {
+		return err
+	}
+
+	err = update(orderBook)
+	if err != nil {
+		return err
+	}
+
+	updateBuilder := s.builder.
+		Update("order_books").
+		SetMap(depthOrderColumnValues(orderBook)).
+		Set("sequence", orderBook.Sequence).
+		Set("resync_required", orderBook.ResyncRequired).
+		Set("updated_at", orderBook.UpdatedAt).
+		Set("exchange_time", orderBook.ExchangeTime).
+		Set("stale", orderBook.Stale).
+		Set("version", sq.Expr("nextval('order_book_version_seq')")).
+		Where(sq.And{sq.Eq{"exchange": exchangeName}, sq.Eq{"pair": pair}}).
+		Suffix("RETURNING version")
+	query, args, err := updateBuilder.ToSql()
 	if err != nil {
 		return errors.Wrap(err, "build query")
 	}
 	slog.Debug(fmt.Sprintf("SQL query: %s", query))
 
-	_, err = s.db.Exec(query, args...)
+	err = tx.QueryRowx(query, args...).Scan(&orderBook.Version)
 	if err != nil {
 		return errors.Wrap(err, "execute query")
 	}
 
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "commit transaction")
+	}
+
 	return nil
 }
 
-func (s *OrderBookStorage) GetOrderBook(exchangeName string, pair string) (bids, asks []domain.DepthOrder, err error) {
+func (s *OrderBookStorage) DeleteOrderBook(exchangeName string, pair string) error {
 	builder := s.builder.
-		Select("bids, asks").
-		From("order_books").
+		Delete("order_books").
 		Where(sq.And{sq.Eq{"exchange": exchangeName}, sq.Eq{"pair": pair}})
 
+	count, err := s.execAffected(builder)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return domain.OrderBookNotFound{Message: "order book not found"}
+	}
+
+	return nil
+}
+
+func (s *OrderBookStorage) DeleteExchangeOrderBooks(exchangeName string) (int64, error) {
+	builder := s.builder.
+		Delete("order_books").
+		Where(sq.Eq{"exchange": exchangeName})
+
+	return s.execAffected(builder)
+}
+
+// GetOrderBookVersions returns versions of all stored order books.
+func (s *OrderBookStorage) GetOrderBookVersions() (map[domain.OrderBookKey]int64, error) {
+	query, args, err := s.builder.
+		Select("exchange, pair, version").
+		From("order_books").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute query")
+	}
+	defer rows.Close()
+
+	versions := make(map[domain.OrderBookKey]int64)
+	for rows.Next() {
+		var key domain.OrderBookKey
+		var version int64
+		err := rows.Scan(&key.ExchangeName, &key.Pair, &version)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan values")
+		}
+		versions[key] = version
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "iterate rows")
+	}
+
+	return versions, nil
+}
+
+// MarkOrderBooksStale flags books updated before updatedBefore that are not flagged yet.
+// Flagged books get a new version, so caches, subscribers and ETags see the change.
+func (s *OrderBookStorage) MarkOrderBooksStale(updatedBefore time.Time) (int64, error) {
+	return s.execAffected(s.markOrderBooksStaleBuilder(updatedBefore))
+}
+
+func (s *OrderBookStorage) markOrderBooksStaleBuilder(updatedBefore time.Time) sq.UpdateBuilder {
+	return s.builder.
+		Update("order_books").
+		Set("stale", true).
+		Set("version", sq.Expr("nextval('order_book_version_seq')")).
+		Where(sq.And{sq.Lt{"updated_at": updatedBefore}, sq.Eq{"stale": false}})
+}
+
+func (s *OrderBookStorage) DeleteOrderBooksUpdatedBefore(updatedBefore time.Time) (int64, error) {
+	builder := s.builder.
+		Delete("order_books").
+		Where(sq.Lt{"updated_at": updatedBefore})
+
+	return s.execAffected(builder)
+}
+
+// GetStoredPairs returns the pairs order books are stored under.
+func (s *OrderBookStorage) GetStoredPairs() ([]domain.OrderBookKey, error) {
+	versions, err := s.GetOrderBookVersions()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]domain.OrderBookKey, 0, len(versions))
+	for key := range versions {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// RenameStoredPair renames the order book of the pair from to the pair to. If a book is
+// already stored under to it was saved after the one under from, which is deleted then.
+func (s *OrderBookStorage) RenameStoredPair(exchangeName, from, to string) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction")
+	}
+	defer tx.Rollback()
+
+	renameBuilder := s.builder.
+		Update("order_books").
+		Set("pair", to).
+		Set("version", sq.Expr("nextval('order_book_version_seq')")).
+		Where(sq.And{
+			sq.Eq{"exchange": exchangeName},
+			sq.Eq{"pair": from},
+			sq.Expr("NOT EXISTS (SELECT 1 FROM order_books WHERE exchange = ? AND pair = ?)", exchangeName, to),
+		})
+	deleteBuilder := s.builder.
+		Delete("order_books").
+		Where(sq.And{sq.Eq{"exchange": exchangeName}, sq.Eq{"pair": from}})
+	for _, builder := range []sq.Sqlizer{renameBuilder, deleteBuilder} {
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return errors.Wrap(err, "build query")
+		}
+		slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+		_, err = tx.Exec(query, args...)
+		if err != nil {
+			return errors.Wrap(err, "execute query")
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "commit transaction")
+	}
+
+	return nil
+}
+
+func (s *OrderBookStorage) execAffected(builder sq.Sqlizer) (int64, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "build query")
+	}
+	slog.Debug(fmt.Sprintf("SQL query: %s", query))
+
+	res, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "execute query")
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "get affected rows")
+	}
+
+	return count, nil
+}
+
+func (s *OrderBookStorage) getOrderBook(db sqlx.Queryer, builder sq.SelectBuilder) (*domain.OrderBook, error) {
 	query, args, err := builder.ToSql()
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "build query")
+		return nil, errors.Wrap(err, "build query")
 	}
 	slog.Debug(fmt.Sprintf("SQL query: %s", query))
 
-	var bidsArray, asksArray DepthOrders
-	err = s.db.QueryRowx(query, args...).Scan(&bidsArray, &asksArray)
+	var row orderBookRow
+	err = db.QueryRowx(query, args...).Scan(row.dest()...)
 	switch err {
 	case nil:
 	case sql.ErrNoRows:
-		return nil, nil, domain.OrderBookNotFound{}
+		return nil, domain.OrderBookNotFound{Message: "order book not found"}
 	default:
-		return nil, nil, errors.Wrap(err, "execute query")
+		return nil, errors.Wrap(err, "execute query")
 	}
 
-	return bidsArray, asksArray, nil
+	return row.orderBook(), nil
 }
 
-func buildDepthOrderArrayExprSQL(depthOrders []domain.DepthOrder) string {
-	expStrSlice := make([]string, 0, len(depthOrders))
-	for i := 0; i < len(depthOrders); i++ {
-		expStrSlice = append(expStrSlice, "ROW(?, ?)::depth_order")
+// orderBookStateColumns are selected after the bid and ask columns.
+const orderBookStateColumns = "sequence, resync_required, updated_at, exchange_time, stale, version"
+
+type orderBookRow struct {
+	bidPrices      decimalArray
+	bidQtys        decimalArray
+	askPrices      decimalArray
+	askQtys        decimalArray
+	sequence       sql.NullInt64
+	resyncRequired bool
+	updatedAt      time.Time
+	exchangeTime   sql.NullTime
+	stale          bool
+	version        int64
+}
+
+// dest returns scan destinations in the order of bid and ask columns and orderBookStateColumns.
+func (r *orderBookRow) dest() []any {
+	return []any{&r.bidPrices, &r.bidQtys, &r.askPrices, &r.askQtys, &r.sequence, &r.resyncRequired, &r.updatedAt, &r.exchangeTime, &r.stale, &r.version}
+}
+
+func (r *orderBookRow) orderBook() *domain.OrderBook {
+	orderBook := &domain.OrderBook{
+		Bids:           joinDepthOrders(r.bidPrices, r.bidQtys),
+		Asks:           joinDepthOrders(r.askPrices, r.askQtys),
+		ResyncRequired: r.resyncRequired,
+		UpdatedAt:      r.updatedAt,
+		Stale:          r.stale,
+		Version:        r.version,
 	}
+	if r.sequence.Valid {
+		orderBook.Sequence = &r.sequence.Int64
+	}
+	if r.exchangeTime.Valid {
+		orderBook.ExchangeTime = &r.exchangeTime.Time
+	}
+	return orderBook
+}
 
-	return fmt.Sprintf("ARRAY[%s]", strings.Join(expStrSlice, ", "))
+// depthOrderArrayColumns selects the price and quantity columns of the side with the given
+// prefix, the first depth levels of them, all levels if depth is 0 and no levels if the side is excluded.
+func depthOrderArrayColumns(prefix string, excluded bool, depth int) sq.Sqlizer {
+	switch {
+	case excluded:
+		return sq.Expr("'{}'::numeric[], '{}'::numeric[]")
+	case depth > 0:
+		return sq.Expr(fmt.Sprintf("%[1]s_prices[1:?], %[1]s_qtys[1:?]", prefix), depth, depth)
+	default:
+		return sq.Expr(fmt.Sprintf("%[1]s_prices, %[1]s_qtys", prefix))
+	}
 }
 
-func flattenDepthOrders(depthOrders []domain.DepthOrder) []any {
-	flatten := make([]any, 0, len(depthOrders))
-	for _, depthOrder := range depthOrders {
-		flatten = append(flatten, depthOrder.Price, depthOrder.BaseQty)
+// depthOrderColumnValues returns values of the bid and ask columns of the book.
+func depthOrderColumnValues(orderBook *domain.OrderBook) map[string]any {
+	bidPrices, bidQtys := splitDepthOrders(orderBook.Bids)
+	askPrices, askQtys := splitDepthOrders(orderBook.Asks)
+	return map[string]any{
+		"bid_prices": decimalArray(bidPrices),
+		"bid_qtys":   decimalArray(bidQtys),
+		"ask_prices": decimalArray(askPrices),
+		"ask_qtys":   decimalArray(askQtys),
 	}
-	return flatten
 }
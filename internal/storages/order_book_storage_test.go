@@ -0,0 +1,423 @@
+package storages
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/crc32"
+	"market-info-storage/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// postgresMaxParams is the number of bind parameters a Postgres statement can have.
+const postgresMaxParams = 65535
+
+func TestDecimalArrayRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name  string
+		array decimalArray
+	}{
+		{
+			name:  "Empty",
+			array: decimalArray{},
+		},
+		{
+			name: "Levels",
+			array: decimalArray{
+				decimal.RequireFromString("0.010782342"),
+				decimal.RequireFromString("24"),
+				decimal.RequireFromString("-1.5"),
+			},
+		},
+		{
+			name: "TrailingZeros",
+			array: decimalArray{
+				decimal.RequireFromString("0.10"),
+				decimal.RequireFromString("1.500"),
+				decimal.RequireFromString("100"),
+			},
+		},
+		{
+			name:  "HugeBook",
+			array: testDecimals(50000),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := tc.array.Value()
+			require.NoError(t, err)
+
+			var scanned decimalArray
+			err = scanned.Scan([]byte(value.(string)))
+			require.NoError(t, err)
+			require.Equal(t, len(tc.array), len(scanned))
+			for i := range tc.array {
+				require.True(t, tc.array[i].Equal(scanned[i]), "element %d: %s != %s", i, tc.array[i], scanned[i])
+				require.Equal(t, tc.array[i].Exponent(), scanned[i].Exponent(), "element %d", i)
+			}
+		})
+	}
+}
+
+func TestDecimalArrayValueKeepsTrailingZeros(t *testing.T) {
+	value, err := decimalArray{decimal.RequireFromString("0.10"), decimal.RequireFromString("24")}.Value()
+	require.NoError(t, err)
+	require.Equal(t, "{0.10,24}", value)
+}
+
+// numericOrderBookStorage keeps the sides of books in the text form of NUMERIC[] columns,
+// so levels reloaded for a delta went through the same encoding as in Postgres.
+type numericOrderBookStorage struct {
+	domain.OrderBookStorage
+
+	rows map[domain.OrderBookKey]numericOrderBookRow
+}
+
+type numericOrderBookRow struct {
+	sides    [4]string
+	sequence sql.NullInt64
+}
+
+func (s *numericOrderBookStorage) SaveOrderBook(exchangeName string, pair string, orderBook *domain.OrderBook) error {
+	s.rows[domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair}] = encodeOrderBookRow(orderBook)
+	return nil
+}
+
+func (s *numericOrderBookStorage) UpdateOrderBook(exchangeName string, pair string, update func(orderBook *domain.OrderBook) error) error {
+	key := domain.OrderBookKey{ExchangeName: exchangeName, Pair: pair}
+	stored, ok := s.rows[key]
+	if !ok {
+		return domain.OrderBookNotFound{Message: "order book not found"}
+	}
+	row := orderBookRow{sequence: stored.sequence}
+	for i, dest := range []*decimalArray{&row.bidPrices, &row.bidQtys, &row.askPrices, &row.askQtys} {
+		if err := dest.Scan([]byte(stored.sides[i])); err != nil {
+			return err
+		}
+	}
+	orderBook := row.orderBook()
+	err := update(orderBook)
+	if err != nil {
+		return err
+	}
+	s.rows[key] = encodeOrderBookRow(orderBook)
+	return nil
+}
+
+func encodeOrderBookRow(orderBook *domain.OrderBook) numericOrderBookRow {
+	bidPrices, bidQtys := splitDepthOrders(orderBook.Bids)
+	askPrices, askQtys := splitDepthOrders(orderBook.Asks)
+	row := numericOrderBookRow{}
+	if orderBook.Sequence != nil {
+		row.sequence = sql.NullInt64{Int64: *orderBook.Sequence, Valid: true}
+	}
+	for i, array := range []decimalArray{bidPrices, bidQtys, askPrices, askQtys} {
+		value, _ := array.Value()
+		row.sides[i] = value.(string)
+	}
+	return row
+}
+
+type nopSnapshotStorage struct {
+	domain.OrderBookSnapshotStorage
+}
+
+func (nopSnapshotStorage) SaveOrderBookSnapshot(exchangeName, pair string, snapshot *domain.OrderBookSnapshot) error {
+	return nil
+}
+
+type fakeExchangeLookup map[string]domain.Exchange
+
+func (l fakeExchangeLookup) LookupExchange(exchangeName string) (domain.Exchange, bool) {
+	exchange, ok := l[exchangeName]
+	return exchange, ok
+}
+
+func TestApplyOrderBookDeltaChecksumAfterReload(t *testing.T) {
+	exchanges := fakeExchangeLookup{"okx": {Name: "okx", Enabled: true}}
+	service := domain.NewOrderBookService(
+		&numericOrderBookStorage{rows: make(map[domain.OrderBookKey]numericOrderBookRow)},
+		nopSnapshotStorage{},
+		domain.NewOrderBookValidator(domain.ValidationModeStrict, nil, nil, exchanges),
+		domain.NewOrderBookHub(16),
+		domain.NewSymbolNormalizer([]string{"USDT"}, nil, nil),
+		nil,
+		exchanges,
+	)
+	level := func(price, qty string) domain.DepthOrder {
+		return domain.DepthOrder{Price: decimal.RequireFromString(price), BaseQty: decimal.RequireFromString(qty)}
+	}
+	checksum := func(fields string) *domain.OrderBookChecksum {
+		return &domain.OrderBookChecksum{Value: int64(int32(crc32.ChecksumIEEE([]byte(fields))))}
+	}
+	sequence := int64(1)
+
+	// OKX checksums are computed over the price strings of the exchange, trailing zeros included
+	err := service.SaveOrderBookSides("okx", "BTC_USDT", &domain.OrderBook{
+		Bids:     []domain.DepthOrder{level("0.10", "1.50")},
+		Asks:     []domain.DepthOrder{level("0.20", "2")},
+		Sequence: &sequence,
+		Checksum: checksum("0.10:1.50:0.20:2"),
+	})
+	require.NoError(t, err)
+
+	err = service.ApplyOrderBookDelta("okx", "BTC_USDT", &domain.OrderBookDelta{
+		Sequence: 2,
+		Asks:     []domain.DepthOrder{level("0.20", "3")},
+		Checksum: checksum("0.10:1.50:0.20:3"),
+	})
+	require.NoError(t, err)
+}
+
+func TestDecimalArrayScanMalformed(t *testing.T) {
+	for _, value := range []string{"", "{", "{1,x}", "(1,2)"} {
+		var scanned decimalArray
+		err := scanned.Scan([]byte(value))
+		require.Error(t, err, value)
+	}
+}
+
+func TestSaveOrderBookParams(t *testing.T) {
+	testCases := []struct {
+		name      string
+		orderBook *domain.OrderBook
+	}{
+		{
+			name:      "EmptySides",
+			orderBook: &domain.OrderBook{Bids: []domain.DepthOrder{}},
+		},
+		{
+			name:      "HugeBook",
+			orderBook: testOrderBook(50000),
+		},
+	}
+
+	storage := NewOrderBookStorage(nil)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, args, err := storage.saveOrderBookBuilder("bybit", "BTC_USDT", tc.orderBook).ToSql()
+			require.NoError(t, err)
+			require.Len(t, args, 9)
+			require.Contains(t, query, "EXCLUDED.bid_prices")
+
+			value, err := args[2].(driver.Valuer).Value()
+			require.NoError(t, err)
+			var bidPrices decimalArray
+			err = bidPrices.Scan([]byte(value.(string)))
+			require.NoError(t, err)
+			require.Len(t, bidPrices, len(tc.orderBook.Bids))
+		})
+	}
+}
+
+func TestMarkOrderBooksStaleBumpsVersion(t *testing.T) {
+	storage := NewOrderBookStorage(nil)
+	query, _, err := storage.markOrderBooksStaleBuilder(time.Now()).ToSql()
+	require.NoError(t, err)
+	// the notify trigger fires on updates of version only
+	require.Contains(t, query, "version = nextval('order_book_version_seq')")
+}
+
+func TestSortByOrderBookKey(t *testing.T) {
+	items := []domain.OrderBookBatchItem{
+		{ExchangeName: "okx", Pair: "BTC_USDT"},
+		{ExchangeName: "bybit", Pair: "ETH_USDT"},
+		{ExchangeName: "bybit", Pair: "BTC_USDT", OrderBook: &domain.OrderBook{Version: 1}},
+		{ExchangeName: "binance", Pair: "SOL_USDT"},
+		{ExchangeName: "bybit", Pair: "BTC_USDT", OrderBook: &domain.OrderBook{Version: 2}},
+	}
+
+	sorted := sortByOrderBookKey(items)
+
+	keys := make([]string, 0, len(sorted))
+	for _, item := range sorted {
+		keys = append(keys, item.ExchangeName+"/"+item.Pair)
+	}
+	require.Equal(t, []string{"binance/SOL_USDT", "bybit/BTC_USDT", "bybit/BTC_USDT", "bybit/ETH_USDT", "okx/BTC_USDT"}, keys)
+	// repeated books keep their batch order, so the last one is saved last
+	require.Equal(t, int64(1), sorted[1].OrderBook.Version)
+	require.Equal(t, int64(2), sorted[2].OrderBook.Version)
+	// the batch order is kept for the caller
+	require.Equal(t, "okx", items[0].ExchangeName)
+}
+
+func TestLegacySaveOrderBookParams(t *testing.T) {
+	_, args, err := legacySaveOrderBookBuilder("bybit", "BTC_USDT", testOrderBook(50000)).ToSql()
+	require.NoError(t, err)
+	require.Greater(t, len(args), postgresMaxParams)
+}
+
+// BenchmarkEncodeOrderBook compares building the upsert of a book and encoding its
+// parameters with parallel arrays against composite depth_order arrays.
+func BenchmarkEncodeOrderBook(b *testing.B) {
+	storage := NewOrderBookStorage(nil)
+	for _, levels := range []int{100, 1000, 10000, 50000} {
+		orderBook := testOrderBook(levels)
+		b.Run(fmt.Sprintf("Arrays/%d", levels), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, args, err := storage.saveOrderBookBuilder("bybit", "BTC_USDT", orderBook).ToSql()
+				if err != nil {
+					b.Fatal(err)
+				}
+				encodeArgs(b, args)
+			}
+		})
+		b.Run(fmt.Sprintf("Composite/%d", levels), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, args, err := legacySaveOrderBookBuilder("bybit", "BTC_USDT", orderBook).ToSql()
+				if err != nil {
+					b.Fatal(err)
+				}
+				encodeArgs(b, args)
+			}
+		})
+	}
+}
+
+// BenchmarkDecodeOrderBook compares scanning a side from parallel arrays against
+// parsing a composite depth_order array.
+func BenchmarkDecodeOrderBook(b *testing.B) {
+	for _, levels := range []int{100, 1000, 10000, 50000} {
+		orderBook := testOrderBook(levels)
+		prices, qtys := splitDepthOrders(orderBook.Bids)
+		pricesValue, _ := decimalArray(prices).Value()
+		qtysValue, _ := decimalArray(qtys).Value()
+		pricesBytes, qtysBytes := []byte(pricesValue.(string)), []byte(qtysValue.(string))
+		compositeBytes := legacyDepthOrderArrayText(orderBook.Bids)
+
+		b.Run(fmt.Sprintf("Arrays/%d", levels), func(b *testing.B) {
+			b.SetBytes(int64(len(pricesBytes) + len(qtysBytes)))
+			for i := 0; i < b.N; i++ {
+				var scannedPrices, scannedQtys decimalArray
+				if err := scannedPrices.Scan(pricesBytes); err != nil {
+					b.Fatal(err)
+				}
+				if err := scannedQtys.Scan(qtysBytes); err != nil {
+					b.Fatal(err)
+				}
+				_ = joinDepthOrders(scannedPrices, scannedQtys)
+			}
+		})
+		b.Run(fmt.Sprintf("Composite/%d", levels), func(b *testing.B) {
+			b.SetBytes(int64(len(compositeBytes)))
+			for i := 0; i < b.N; i++ {
+				var scanned legacyDepthOrders
+				if err := scanned.Scan(compositeBytes); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// encodeArgs converts the parameters the way the driver does before sending them.
+func encodeArgs(b *testing.B, args []any) {
+	for _, arg := range args {
+		if _, err := driver.DefaultParameterConverter.ConvertValue(arg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func testDecimals(n int) decimalArray {
+	decimals := make(decimalArray, 0, n)
+	for i := 0; i < n; i++ {
+		decimals = append(decimals, decimal.New(int64(6700000000+i*37), -5))
+	}
+	return decimals
+}
+
+func testOrderBook(levels int) *domain.OrderBook {
+	prices := testDecimals(2 * levels)
+	orderBook := &domain.OrderBook{UpdatedAt: time.Now()}
+	for i := 0; i < levels; i++ {
+		qty := decimal.New(int64(i%1000+1), -3)
+		orderBook.Bids = append(orderBook.Bids, domain.DepthOrder{Price: prices[levels-1-i], BaseQty: qty})
+		orderBook.Asks = append(orderBook.Asks, domain.DepthOrder{Price: prices[levels+i], BaseQty: qty})
+	}
+	return orderBook
+}
+
+// legacySaveOrderBookBuilder is the upsert of the composite depth_order[] layout,
+// which binds every price and quantity twice.
+func legacySaveOrderBookBuilder(exchangeName string, pair string, orderBook *domain.OrderBook) sq.InsertBuilder {
+	bidsExpr := sq.Expr(legacyDepthOrderArrayExprSQL(orderBook.Bids), legacyFlattenDepthOrders(orderBook.Bids)...)
+	asksExpr := sq.Expr(legacyDepthOrderArrayExprSQL(orderBook.Asks), legacyFlattenDepthOrders(orderBook.Asks)...)
+	return sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("order_books").
+		Columns("exchange, pair, bids, asks, sequence, updated_at, exchange_time").
+		Values(exchangeName, pair, bidsExpr, asksExpr, orderBook.Sequence, orderBook.UpdatedAt, orderBook.ExchangeTime).
+		Suffix(`ON CONFLICT (exchange, pair)
+				DO UPDATE SET bids = ?, asks = ?, sequence = ?, resync_required = FALSE,
+				updated_at = ?, exchange_time = ?, stale = FALSE, version = nextval('order_book_version_seq')
+			RETURNING version`,
+			bidsExpr, asksExpr, orderBook.Sequence, orderBook.UpdatedAt, orderBook.ExchangeTime)
+}
+
+func legacyDepthOrderArrayExprSQL(depthOrders []domain.DepthOrder) string {
+	expStrSlice := make([]string, 0, len(depthOrders))
+	for i := 0; i < len(depthOrders); i++ {
+		expStrSlice = append(expStrSlice, "ROW(?, ?)::depth_order")
+	}
+
+	return fmt.Sprintf("ARRAY[%s]::depth_order[]", strings.Join(expStrSlice, ", "))
+}
+
+func legacyFlattenDepthOrders(depthOrders []domain.DepthOrder) []any {
+	flatten := make([]any, 0, len(depthOrders))
+	for _, depthOrder := range depthOrders {
+		flatten = append(flatten, depthOrder.Price, depthOrder.BaseQty)
+	}
+	return flatten
+}
+
+// legacyDepthOrderArrayText is the text form Postgres outputs for depth_order[].
+func legacyDepthOrderArrayText(depthOrders []domain.DepthOrder) []byte {
+	elems := make([]string, 0, len(depthOrders))
+	for _, depthOrder := range depthOrders {
+		elems = append(elems, fmt.Sprintf(`"(%s,%s)"`, depthOrder.Price, depthOrder.BaseQty))
+	}
+	return []byte("{" + strings.Join(elems, ",") + "}")
+}
+
+// legacyDepthOrders parses the text form of depth_order[].
+type legacyDepthOrders []domain.DepthOrder
+
+func (a *legacyDepthOrders) Scan(value interface{}) error {
+	arrayBytes := value.([]byte)
+	if bytes.Equal(arrayBytes, []byte("{}")) {
+		*a = legacyDepthOrders{}
+		return nil
+	}
+	arrayBytes = arrayBytes[2 : len(arrayBytes)-2] // trim {" and "}
+
+	splitArrayBytes := bytes.Split(arrayBytes, []byte{'"', ',', '"'})
+	res := make([]domain.DepthOrder, 0, len(splitArrayBytes))
+	for _, depthOrderBytes := range splitArrayBytes {
+		depthOrderBytes = depthOrderBytes[1 : len(depthOrderBytes)-1] // trim ( and )
+		splitDepthOrderBytes := bytes.Split(depthOrderBytes, []byte{','})
+
+		var err error
+		var depthOrder domain.DepthOrder
+		depthOrder.Price, err = decimal.NewFromString(string(splitDepthOrderBytes[0]))
+		if err != nil {
+			return err
+		}
+		depthOrder.BaseQty, err = decimal.NewFromString(string(splitDepthOrderBytes[1]))
+		if err != nil {
+			return err
+		}
+		res = append(res, depthOrder)
+	}
+
+	*a = res
+	return nil
+}